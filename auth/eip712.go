@@ -1,10 +1,14 @@
 package auth
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -16,7 +20,8 @@ const (
 	MSG_TO_SIGN = "This message attests that I control the given wallet"
 )
 
-// EIP712Domain represents the EIP-712 domain
+// EIP712Domain represents the EIP-712 domain. Only the fields that are set
+// are part of the domain type.
 type EIP712Domain struct {
 	Name              string `json:"name"`
 	Version           string `json:"version"`
@@ -25,6 +30,38 @@ type EIP712Domain struct {
 	VerifyingContract string `json:"verifyingContract,omitempty"`
 }
 
+// Types returns the EIP712Domain type definition for the fields that are set
+func (d EIP712Domain) Types() []EIP712Type {
+	var fields []EIP712Type
+	if d.Name != "" {
+		fields = append(fields, EIP712Type{Name: "name", Type: "string"})
+	}
+	if d.Version != "" {
+		fields = append(fields, EIP712Type{Name: "version", Type: "string"})
+	}
+	if d.ChainID != 0 {
+		fields = append(fields, EIP712Type{Name: "chainId", Type: "uint256"})
+	}
+	if d.VerifyingContract != "" {
+		fields = append(fields, EIP712Type{Name: "verifyingContract", Type: "address"})
+	}
+	if d.Salt != "" {
+		fields = append(fields, EIP712Type{Name: "salt", Type: "bytes32"})
+	}
+	return fields
+}
+
+// values returns the domain as a message for encoding
+func (d EIP712Domain) values() map[string]interface{} {
+	return map[string]interface{}{
+		"name":              d.Name,
+		"version":           d.Version,
+		"chainId":           d.ChainID,
+		"verifyingContract": d.VerifyingContract,
+		"salt":              d.Salt,
+	}
+}
+
 // EIP712Type represents EIP-712 type definition
 type EIP712Type struct {
 	Name string `json:"name"`
@@ -39,7 +76,9 @@ type ClobAuthData struct {
 	Message   string `json:"message"`
 }
 
-// TypedData represents the full EIP-712 typed data structure
+// TypedData represents the full EIP-712 typed data structure. Message may be
+// a map or any value that marshals to a JSON object; integers may be given as
+// numbers, decimal or hex strings, or *big.Int.
 type TypedData struct {
 	Types       map[string][]EIP712Type `json:"types"`
 	PrimaryType string                  `json:"primaryType"`
@@ -47,186 +86,456 @@ type TypedData struct {
 	Message     interface{}             `json:"message"`
 }
 
-// BuildClobEip712Signature builds the canonical Polymarket CLOB EIP712 signature
-func BuildClobEip712Signature(privateKey *ecdsa.PrivateKey, chainID int64, timestamp int64, nonce uint64) (string, error) {
-	// Get address from private key
-	address := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
-
-	// Create domain
-	domain := EIP712Domain{
-		Name:    "ClobAuthDomain",
-		Version: "1",
-		ChainID: chainID,
-	}
-
-	// Create types
-	types := map[string][]EIP712Type{
-		"ClobAuth": {
-			{Name: "address", Type: "address"},
-			{Name: "timestamp", Type: "string"},
-			{Name: "nonce", Type: "uint256"},
-			{Name: "message", Type: "string"},
+// ClobAuthTypedData returns the typed data signed for L1 authentication
+func ClobAuthTypedData(address string, chainID int64, timestamp int64, nonce uint64) TypedData {
+	return TypedData{
+		Types: map[string][]EIP712Type{
+			"ClobAuth": {
+				{Name: "address", Type: "address"},
+				{Name: "timestamp", Type: "string"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "message", Type: "string"},
+			},
+		},
+		PrimaryType: "ClobAuth",
+		Domain: EIP712Domain{
+			Name:    "ClobAuthDomain",
+			Version: "1",
+			ChainID: chainID,
+		},
+		Message: ClobAuthData{
+			Address:   address,
+			Timestamp: strconv.FormatInt(timestamp, 10),
+			Nonce:     nonce,
+			Message:   MSG_TO_SIGN,
 		},
 	}
+}
+
+// BuildClobEip712Signature builds the canonical Polymarket CLOB EIP712 signature
+func BuildClobEip712Signature(signer Signer, chainID int64, timestamp int64, nonce uint64) (string, error) {
+	address := signer.GetAddress().Hex()
+	return signer.SignTypedData(ClobAuthTypedData(address, chainID, timestamp, nonce))
+}
 
-	// Create message data
-	message := ClobAuthData{
-		Address:   address,
-		Timestamp: fmt.Sprintf("%d", timestamp),
-		Nonce:     nonce,
-		Message:   MSG_TO_SIGN,
+// SignTypedData signs EIP-712 typed data using the private key
+func SignTypedData(privateKey *ecdsa.PrivateKey, typedData TypedData) (string, error) {
+	hash, err := HashTypedData(typedData)
+	if err != nil {
+		return "", fmt.Errorf("failed to get typed data hash: %w", err)
+	}
+
+	return signHash(privateKey, hash)
+}
+
+// HashTypedData computes the EIP-712 signing hash:
+// keccak256("\x19\x01" || domainSeparator || hashStruct(message))
+func HashTypedData(typedData TypedData) (common.Hash, error) {
+	types := make(map[string][]EIP712Type, len(typedData.Types)+1)
+	for name, fields := range typedData.Types {
+		types[name] = fields
+	}
+	if _, ok := types["EIP712Domain"]; !ok {
+		types["EIP712Domain"] = typedData.Domain.Types()
 	}
 
-	// Generate the sign hash according to EIP-712
-	domainSeparator, err := getDomainSeparator(domain)
+	domainSeparator, err := hashStruct("EIP712Domain", typedData.Domain.values(), types)
 	if err != nil {
-		return "", fmt.Errorf("failed to get domain separator: %w", err)
+		return common.Hash{}, fmt.Errorf("failed to hash domain: %w", err)
 	}
 
-	typeHash, err := getTypeHash(types["ClobAuth"])
+	message, err := toMessage(typedData.Message)
 	if err != nil {
-		return "", fmt.Errorf("failed to get type hash: %w", err)
+		return common.Hash{}, err
 	}
 
-	encodeData, err := encodeClobAuthData(message)
+	messageHash, err := hashStruct(typedData.PrimaryType, message, types)
 	if err != nil {
-		return "", fmt.Errorf("failed to encode data: %w", err)
+		return common.Hash{}, fmt.Errorf("failed to hash message: %w", err)
 	}
 
-	// Hash the struct: keccak256(typeHash || encodeData)
-	structHash := crypto.Keccak256Hash(append(typeHash.Bytes(), encodeData...))
+	return crypto.Keccak256Hash([]byte("\x19\x01"), domainSeparator.Bytes(), messageHash.Bytes()), nil
+}
 
-	// Construct the final hash: keccak256("\x19\x01" || domainSeparator || structHash)
-	hash := crypto.Keccak256Hash(
-		append(append([]byte("\x19\x01"), domainSeparator.Bytes()...), structHash.Bytes()...),
-	)
+// EncodeType returns the EIP-712 type string of a struct type, followed by
+// the definitions of the struct types it references in alphabetical order
+func EncodeType(primaryType string, types map[string][]EIP712Type) (string, error) {
+	if _, ok := types[primaryType]; !ok {
+		return "", fmt.Errorf("unknown type %s", primaryType)
+	}
 
-	// Sign the hash
-	signature, err := crypto.Sign(hash.Bytes(), privateKey)
+	deps := make(map[string]bool)
+	collectDependencies(primaryType, types, deps)
+	delete(deps, primaryType)
+
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range append([]string{primaryType}, names...) {
+		b.WriteString(name)
+		b.WriteByte('(')
+		for i, field := range types[name] {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(field.Type)
+			b.WriteByte(' ')
+			b.WriteString(field.Name)
+		}
+		b.WriteByte(')')
+	}
+
+	return b.String(), nil
+}
+
+// TypeHash returns keccak256 of the encoded type
+func TypeHash(primaryType string, types map[string][]EIP712Type) (common.Hash, error) {
+	encoded, err := EncodeType(primaryType, types)
 	if err != nil {
-		return "", fmt.Errorf("failed to sign hash: %w", err)
+		return common.Hash{}, err
 	}
+	return crypto.Keccak256Hash([]byte(encoded)), nil
+}
 
-	// Adjust v value from 0/1 to 27/28 (Ethereum standard)
-	if signature[64] < 27 {
-		signature[64] += 27
+func collectDependencies(typeName string, types map[string][]EIP712Type, deps map[string]bool) {
+	if deps[typeName] {
+		return
+	}
+	fields, ok := types[typeName]
+	if !ok {
+		return
 	}
 
-	// Convert signature to hex string
-	signatureHex := hexutil.Encode(signature)
+	deps[typeName] = true
+	for _, field := range fields {
+		collectDependencies(baseType(field.Type), types, deps)
+	}
+}
 
-	return signatureHex, nil
+// baseType strips array suffixes from a type, e.g. Person[][2] -> Person
+func baseType(typ string) string {
+	if i := strings.IndexByte(typ, '['); i >= 0 {
+		return typ[:i]
+	}
+	return typ
 }
 
-// getDomainSeparator creates the domain separator hash according to EIP-712
-func getDomainSeparator(domain EIP712Domain) (common.Hash, error) {
-	// EIP712Domain(string name,string version,uint256 chainId)
-	typeHash := crypto.Keccak256Hash([]byte("EIP712Domain(string name,string version,uint256 chainId)"))
+// hashStruct computes keccak256(typeHash || encodeData(data))
+func hashStruct(typeName string, data map[string]interface{}, types map[string][]EIP712Type) (common.Hash, error) {
+	typeHash, err := TypeHash(typeName, types)
+	if err != nil {
+		return common.Hash{}, err
+	}
 
-	// Hash the domain fields
-	nameHash := crypto.Keccak256Hash([]byte(domain.Name))
-	versionHash := crypto.Keccak256Hash([]byte(domain.Version))
+	encoded := typeHash.Bytes()
+	for _, field := range types[typeName] {
+		value, ok := data[field.Name]
+		if !ok {
+			return common.Hash{}, fmt.Errorf("missing field %s.%s", typeName, field.Name)
+		}
+
+		word, err := encodeValue(field.Type, value, types)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("failed to encode %s.%s: %w", typeName, field.Name, err)
+		}
+		encoded = append(encoded, word...)
+	}
 
-	// Encode chainId as uint256 (32 bytes)
-	chainId := new(big.Int).SetInt64(domain.ChainID)
-	chainIdBytes := make([]byte, 32)
-	chainId.FillBytes(chainIdBytes)
+	return crypto.Keccak256Hash(encoded), nil
+}
 
-	// Concatenate: typeHash || nameHash || versionHash || chainId
-	data := append(typeHash.Bytes(), nameHash.Bytes()...)
-	data = append(data, versionHash.Bytes()...)
-	data = append(data, chainIdBytes...)
+// encodeValue encodes a single value to its 32 byte EIP-712 representation
+func encodeValue(typ string, value interface{}, types map[string][]EIP712Type) ([]byte, error) {
+	// Arrays are encoded as the hash of their concatenated encoded elements
+	if strings.HasSuffix(typ, "]") {
+		open := strings.LastIndexByte(typ, '[')
+		elemType := typ[:open]
+
+		items, err := toSlice(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if size := typ[open+1 : len(typ)-1]; size != "" {
+			n, err := strconv.Atoi(size)
+			if err != nil {
+				return nil, fmt.Errorf("invalid array type %s", typ)
+			}
+			if len(items) != n {
+				return nil, fmt.Errorf("expected %d elements for %s, got %d", n, typ, len(items))
+			}
+		}
+
+		var encoded []byte
+		for _, item := range items {
+			word, err := encodeValue(elemType, item, types)
+			if err != nil {
+				return nil, err
+			}
+			encoded = append(encoded, word...)
+		}
+		return crypto.Keccak256(encoded), nil
+	}
 
-	return crypto.Keccak256Hash(data), nil
-}
+	if _, ok := types[typ]; ok {
+		data, err := toMessage(value)
+		if err != nil {
+			return nil, err
+		}
+		hash, err := hashStruct(typ, data, types)
+		if err != nil {
+			return nil, err
+		}
+		return hash.Bytes(), nil
+	}
 
-// getTypeHash creates the type hash for ClobAuth
-func getTypeHash(types []EIP712Type) (common.Hash, error) {
-	// Build the type string: "ClobAuth(address address,string timestamp,uint256 nonce,string message)"
-	typeString := "ClobAuth(address address,string timestamp,uint256 nonce,string message)"
-	return crypto.Keccak256Hash([]byte(typeString)), nil
+	switch {
+	case typ == "string":
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %T", value)
+		}
+		return crypto.Keccak256([]byte(s)), nil
+
+	case typ == "bytes":
+		b, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		return crypto.Keccak256(b), nil
+
+	case strings.HasPrefix(typ, "bytes"):
+		n, err := strconv.Atoi(typ[len("bytes"):])
+		if err != nil || n < 1 || n > 32 {
+			return nil, fmt.Errorf("invalid type %s", typ)
+		}
+		b, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) > n {
+			return nil, fmt.Errorf("expected at most %d bytes for %s, got %d", n, typ, len(b))
+		}
+		// Fixed-size bytes are right-padded
+		word := make([]byte, 32)
+		copy(word, b)
+		return word, nil
+
+	case typ == "bool":
+		var b bool
+		switch v := value.(type) {
+		case bool:
+			b = v
+		case string:
+			parsed, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid bool %q", v)
+			}
+			b = parsed
+		default:
+			return nil, fmt.Errorf("expected bool, got %T", value)
+		}
+		word := make([]byte, 32)
+		if b {
+			word[31] = 1
+		}
+		return word, nil
+
+	case typ == "address":
+		var address common.Address
+		switch v := value.(type) {
+		case string:
+			if !common.IsHexAddress(v) {
+				return nil, fmt.Errorf("invalid address %q", v)
+			}
+			address = common.HexToAddress(v)
+		case common.Address:
+			address = v
+		default:
+			return nil, fmt.Errorf("expected address, got %T", value)
+		}
+		return common.LeftPadBytes(address.Bytes(), 32), nil
+
+	case strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "int"):
+		return encodeInteger(typ, value)
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", typ)
 }
 
-// encodeClobAuthData encodes the ClobAuth data according to EIP-712
-func encodeClobAuthData(data ClobAuthData) ([]byte, error) {
-	address := common.HexToAddress(data.Address)
-	nonce := new(big.Int).SetUint64(data.Nonce)
+// encodeInteger encodes uintN/intN values, using two's complement for negatives
+func encodeInteger(typ string, value interface{}) ([]byte, error) {
+	signed := strings.HasPrefix(typ, "int")
+	bits := 256
+	if size := strings.TrimPrefix(strings.TrimPrefix(typ, "u"), "int"); size != "" {
+		n, err := strconv.Atoi(size)
+		if err != nil || n < 8 || n > 256 || n%8 != 0 {
+			return nil, fmt.Errorf("invalid type %s", typ)
+		}
+		bits = n
+	}
 
-	// Encode address (padded to 32 bytes, left-padded)
-	addressBytes := make([]byte, 32)
-	copy(addressBytes[12:], address.Bytes()) // address is 20 bytes, so left-pad with 12 zeros
+	n, err := toBigInt(value)
+	if err != nil {
+		return nil, err
+	}
 
-	// Encode timestamp as keccak256 hash of the string
-	timestampHash := crypto.Keccak256Hash([]byte(data.Timestamp))
+	if signed {
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+		if n.Cmp(limit) >= 0 || n.Cmp(new(big.Int).Neg(limit)) < 0 {
+			return nil, fmt.Errorf("value %s overflows %s", n, typ)
+		}
+	} else if n.Sign() < 0 || n.BitLen() > bits {
+		return nil, fmt.Errorf("value %s overflows %s", n, typ)
+	}
 
-	// Encode nonce as uint256 (32 bytes, big-endian)
-	nonceBytes := make([]byte, 32)
-	nonce.FillBytes(nonceBytes)
+	if n.Sign() < 0 {
+		n = new(big.Int).Add(n, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
 
-	// Encode message as keccak256 hash of the string
-	messageHash := crypto.Keccak256Hash([]byte(data.Message))
+	word := make([]byte, 32)
+	n.FillBytes(word)
+	return word, nil
+}
 
-	// Concatenate all encoded data
-	encodedData := append(addressBytes, timestampHash.Bytes()...)
-	encodedData = append(encodedData, nonceBytes...)
-	encodedData = append(encodedData, messageHash.Bytes()...)
+// toMessage converts a struct value to a map, keeping numbers exact
+func toMessage(value interface{}) (map[string]interface{}, error) {
+	if m, ok := value.(map[string]interface{}); ok {
+		return m, nil
+	}
 
-	return encodedData, nil
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var m map[string]interface{}
+	if err := decoder.Decode(&m); err != nil {
+		return nil, fmt.Errorf("failed to decode message: %w", err)
+	}
+
+	return m, nil
 }
 
-// SignTypedData signs EIP-712 typed data using the private key
-func SignTypedData(privateKey *ecdsa.PrivateKey, typedData TypedData) (string, error) {
-	// This is a more complete implementation that follows the EIP-712 spec exactly
-	hash, err := getTypedDataHash(typedData)
-	if err != nil {
-		return "", fmt.Errorf("failed to get typed data hash: %w", err)
+func toSlice(value interface{}) ([]interface{}, error) {
+	if items, ok := value.([]interface{}); ok {
+		return items, nil
 	}
 
-	// Sign the hash
-	signature, err := crypto.Sign(hash.Bytes(), privateKey)
+	raw, err := json.Marshal(value)
 	if err != nil {
-		return "", fmt.Errorf("failed to sign hash: %w", err)
+		return nil, fmt.Errorf("failed to marshal array: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var items []interface{}
+	if err := decoder.Decode(&items); err != nil {
+		return nil, fmt.Errorf("expected array, got %T", value)
 	}
 
-	// Convert to hex string
-	signatureHex := hexutil.Encode(signature)
+	return items, nil
+}
 
-	return signatureHex, nil
+func toBigInt(value interface{}) (*big.Int, error) {
+	switch v := value.(type) {
+	case *big.Int:
+		if v == nil {
+			return nil, fmt.Errorf("nil integer")
+		}
+		return v, nil
+	case big.Int:
+		return &v, nil
+	case json.Number:
+		return parseBigInt(v.String())
+	case string:
+		return parseBigInt(v)
+	case float64:
+		if v != float64(int64(v)) {
+			return nil, fmt.Errorf("non-integer value %v", v)
+		}
+		return big.NewInt(int64(v)), nil
+	case int:
+		return big.NewInt(int64(v)), nil
+	case int32:
+		return big.NewInt(int64(v)), nil
+	case int64:
+		return big.NewInt(v), nil
+	case uint8:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint32:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	case uint:
+		return new(big.Int).SetUint64(uint64(v)), nil
+	}
+	return nil, fmt.Errorf("expected integer, got %T", value)
 }
 
-// getTypedDataHash computes the hash of typed data according to EIP-712
-func getTypedDataHash(typedData TypedData) (common.Hash, error) {
-	// Hash the domain separator
-	domainSeparator, err := getDomainSeparator(typedData.Domain)
-	if err != nil {
-		return common.Hash{}, err
+func parseBigInt(s string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %q", s)
 	}
+	return n, nil
+}
 
-	// Hash the message
-	messageHash, err := getMessageHash(typedData)
+func toBytes(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		b, err := hexutil.Decode(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex bytes %q: %w", v, err)
+		}
+		return b, nil
+	case common.Hash:
+		return v.Bytes(), nil
+	}
+	return nil, fmt.Errorf("expected bytes, got %T", value)
+}
+
+// signHash signs a hash, returning the signature with v normalized to 27/28
+func signHash(privateKey *ecdsa.PrivateKey, hash common.Hash) (string, error) {
+	signature, err := crypto.Sign(hash.Bytes(), privateKey)
 	if err != nil {
-		return common.Hash{}, err
+		return "", fmt.Errorf("failed to sign hash: %w", err)
 	}
 
-	// Construct final hash: keccak256("||" || domainSeparator || messageHash)
-	finalHash := crypto.Keccak256Hash(
-		[]byte("\x19\x01"),
-		domainSeparator.Bytes(),
-		messageHash.Bytes(),
-	)
+	// Adjust v value from 0/1 to 27/28 (Ethereum standard)
+	if signature[64] < 27 {
+		signature[64] += 27
+	}
 
-	return finalHash, nil
+	return hexutil.Encode(signature), nil
 }
 
-// getMessageHash hashes the message part of typed data
-func getMessageHash(typedData TypedData) (common.Hash, error) {
-	// Convert message to bytes
-	messageBytes, err := json.Marshal(typedData.Message)
+// normalizeSignature returns a hex signature with v as 27/28
+func normalizeSignature(signature string) (string, error) {
+	sig, err := hexutil.Decode(signature)
 	if err != nil {
-		return common.Hash{}, fmt.Errorf("failed to marshal message: %w", err)
+		return "", fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	if len(sig) != 65 {
+		return "", fmt.Errorf("signature must be 65 bytes long")
+	}
+
+	if sig[64] < 27 {
+		sig[64] += 27
 	}
 
-	return crypto.Keccak256Hash(messageBytes), nil
+	return hexutil.Encode(sig), nil
 }
 
 // RecoverAddress recovers the address from a signature
@@ -240,9 +549,9 @@ func RecoverAddress(hash common.Hash, signature string) (common.Address, error)
 		return common.Address{}, fmt.Errorf("signature must be 65 bytes long")
 	}
 
-	// Adjust v value if needed (go-ethereum expects 27 or 28)
-	if sig[64] != 27 && sig[64] != 28 {
-		sig[64] += 27
+	// crypto.SigToPub expects a recovery id of 0 or 1
+	if sig[64] >= 27 {
+		sig[64] -= 27
 	}
 
 	pubkey, err := crypto.SigToPub(hash.Bytes(), sig)
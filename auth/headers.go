@@ -1,18 +1,16 @@
 package auth
 
 import (
-	"crypto/ecdsa"
 	"fmt"
 	"strconv"
 	"time"
 
-	"github.com/ethereum/go-ethereum/common/hexutil"
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/lixvyang/polymarket-sdk-go/types"
 )
 
 // CreateL1Headers creates Level 1 authentication headers for API key creation
-func CreateL1Headers(privateKey *ecdsa.PrivateKey, chainID types.Chain, nonce *uint64, timestamp *int64) (*types.L1PolyHeader, error) {
+func CreateL1Headers(signer Signer, chainID types.Chain, nonce *uint64, timestamp *int64) (*types.L1PolyHeader, error) {
 	// Default timestamp to current time if not provided
 	ts := time.Now().Unix()
 	if timestamp != nil {
@@ -26,13 +24,12 @@ func CreateL1Headers(privateKey *ecdsa.PrivateKey, chainID types.Chain, nonce *u
 	}
 
 	// Build EIP712 signature
-	sig, err := BuildClobEip712Signature(privateKey, int64(chainID), ts, n)
+	sig, err := BuildClobEip712Signature(signer, int64(chainID), ts, n)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build EIP712 signature: %w", err)
 	}
 
-	// Get address from private key
-	address := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	address := signer.GetAddress().Hex()
 
 	headers := &types.L1PolyHeader{
 		POLYAddress:   address,
@@ -45,15 +42,14 @@ func CreateL1Headers(privateKey *ecdsa.PrivateKey, chainID types.Chain, nonce *u
 }
 
 // CreateL2Headers creates Level 2 authentication headers for API operations
-func CreateL2Headers(privateKey *ecdsa.PrivateKey, creds *types.ApiKeyCreds, l2HeaderArgs *types.L2HeaderArgs, timestamp *int64) (*types.L2PolyHeader, error) {
+func CreateL2Headers(signer Signer, creds *types.ApiKeyCreds, l2HeaderArgs *types.L2HeaderArgs, timestamp *int64) (*types.L2PolyHeader, error) {
 	// Default timestamp to current time if not provided
 	ts := time.Now().Unix()
 	if timestamp != nil {
 		ts = *timestamp
 	}
 
-	// Get address from private key
-	address := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	address := signer.GetAddress().Hex()
 
 	// Build HMAC signature
 	var body *string
@@ -97,12 +93,15 @@ func (bc *BuilderConfig) IsValid() bool {
 
 // GenerateBuilderHeaders generates builder headers
 func (bc *BuilderConfig) GenerateBuilderHeaders(method string, path string, body *string) (*L2WithBuilderHeader, error) {
+	return bc.GenerateBuilderHeadersAt(method, path, body, time.Now().Unix())
+}
+
+// GenerateBuilderHeadersAt generates builder headers for the given Unix timestamp
+func (bc *BuilderConfig) GenerateBuilderHeadersAt(method string, path string, body *string, ts int64) (*L2WithBuilderHeader, error) {
 	if !bc.IsValid() {
 		return nil, fmt.Errorf("invalid builder config")
 	}
 
-	ts := time.Now().Unix()
-
 	var bodyStr *string
 	if body != nil {
 		bodyStr = body
@@ -135,41 +134,11 @@ func InjectBuilderHeaders(l2Headers *types.L2PolyHeader, builderHeaders *L2WithB
 
 // VerifyEIP712Signature verifies an EIP712 signature
 func VerifyEIP712Signature(address string, signature string, timestamp int64, nonce uint64, chainID types.Chain) (bool, error) {
-	// Parse the signature
-	_, err := hexutil.Decode(signature)
-	if err != nil {
-		return false, fmt.Errorf("failed to decode signature: %w", err)
-	}
-
-	// Create the typed data hash
-	domain := EIP712Domain{
-		Name:    "ClobAuthDomain",
-		Version: "1",
-		ChainID: int64(chainID),
-	}
-
-	message := ClobAuthData{
-		Address:   address,
-		Timestamp: fmt.Sprintf("%d", timestamp),
-		Nonce:     nonce,
-		Message:   MSG_TO_SIGN,
-	}
-
-	typedData := TypedData{
-		Types: map[string][]EIP712Type{
-			"ClobAuth": {
-				{Name: "address", Type: "address"},
-				{Name: "timestamp", Type: "string"},
-				{Name: "nonce", Type: "uint256"},
-				{Name: "message", Type: "string"},
-			},
-		},
-		PrimaryType: "ClobAuth",
-		Domain:      domain,
-		Message:     message,
+	if !common.IsHexAddress(address) {
+		return false, fmt.Errorf("invalid address %q", address)
 	}
 
-	hash, err := getTypedDataHash(typedData)
+	hash, err := HashTypedData(ClobAuthTypedData(address, int64(chainID), timestamp, nonce))
 	if err != nil {
 		return false, fmt.Errorf("failed to get typed data hash: %w", err)
 	}
@@ -180,5 +149,5 @@ func VerifyEIP712Signature(address string, signature string, timestamp int64, no
 		return false, fmt.Errorf("failed to recover address: %w", err)
 	}
 
-	return recoveredAddress.Hex() == address, nil
+	return recoveredAddress == common.HexToAddress(address), nil
 }
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/google/uuid"
+)
+
+// Scrypt parameters of new keystores. Tests lower them to the light ones.
+var (
+	keystoreScryptN = keystore.StandardScryptN
+	keystoreScryptP = keystore.StandardScryptP
+)
+
+// NewWalletFromKeystore loads a wallet from an Ethereum V3 keystore file
+func NewWalletFromKeystore(path string, passphrase string) (*Wallet, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore: %w", err)
+	}
+
+	return NewWalletFromKeystoreJSON(keyJSON, passphrase)
+}
+
+// NewWalletFromKeystoreJSON loads a wallet from Ethereum V3 keystore JSON
+func NewWalletFromKeystoreJSON(keyJSON []byte, passphrase string) (*Wallet, error) {
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore: %w", err)
+	}
+
+	return NewWalletFromPrivateKey(key.PrivateKey), nil
+}
+
+// EncryptKeystore encrypts the wallet key as Ethereum V3 keystore JSON
+func (w *Wallet) EncryptKeystore(passphrase string) ([]byte, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key id: %w", err)
+	}
+
+	key := &keystore.Key{
+		Id:         id,
+		Address:    w.address,
+		PrivateKey: w.privateKey,
+	}
+
+	keyJSON, err := keystore.EncryptKey(key, passphrase, keystoreScryptN, keystoreScryptP)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt key: %w", err)
+	}
+
+	return keyJSON, nil
+}
+
+// SaveKeystore writes the wallet key to an Ethereum V3 keystore file,
+// readable only by the owner. It fails if the file already exists.
+func (w *Wallet) SaveKeystore(path string, passphrase string) error {
+	keyJSON, err := w.EncryptKeystore(passphrase)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create keystore file: %w", err)
+	}
+
+	if _, err := file.Write(keyJSON); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write keystore: %w", err)
+	}
+
+	return file.Close()
+}
+
+// NewRandomKeystore creates a wallet with a random private key and saves it
+// to a keystore file
+func NewRandomKeystore(path string, passphrase string) (*Wallet, error) {
+	wallet, err := NewRandomWallet()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := wallet.SaveKeystore(path, passphrase); err != nil {
+		return nil, err
+	}
+
+	return wallet, nil
+}
@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+)
+
+// lightScrypt makes keystore encryption fast for the test
+func lightScrypt(t *testing.T) {
+	n, p := keystoreScryptN, keystoreScryptP
+	keystoreScryptN, keystoreScryptP = keystore.LightScryptN, keystore.LightScryptP
+	t.Cleanup(func() { keystoreScryptN, keystoreScryptP = n, p })
+}
+
+func TestKeystoreRoundTrip(t *testing.T) {
+	lightScrypt(t)
+
+	wallet, err := NewWalletFromHex(testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyJSON, err := wallet.EncryptKeystore("passphrase")
+	if err != nil {
+		t.Fatalf("EncryptKeystore failed: %v", err)
+	}
+
+	var header struct {
+		Address string `json:"address"`
+		Version int    `json:"version"`
+		Crypto  struct {
+			KDF       string         `json:"kdf"`
+			KDFParams map[string]any `json:"kdfparams"`
+		} `json:"crypto"`
+	}
+	if err := json.Unmarshal(keyJSON, &header); err != nil {
+		t.Fatal(err)
+	}
+	if header.Version != 3 || header.Address != "f39fd6e51aad88f6f4ce6ab8827279cfffb92266" || header.Crypto.KDF != "scrypt" {
+		t.Errorf("keystore header = %+v", header)
+	}
+	if header.Crypto.KDFParams["n"] != float64(keystore.LightScryptN) {
+		t.Errorf("scrypt n = %v, want %d", header.Crypto.KDFParams["n"], keystore.LightScryptN)
+	}
+
+	loaded, err := NewWalletFromKeystoreJSON(keyJSON, "passphrase")
+	if err != nil {
+		t.Fatalf("NewWalletFromKeystoreJSON failed: %v", err)
+	}
+	if loaded.GetAddress() != wallet.GetAddress() || PrivateKeyToHex(loaded.GetPrivateKey()) != PrivateKeyToHex(wallet.GetPrivateKey()) {
+		t.Errorf("loaded wallet %s differs from %s", loaded.GetAddress().Hex(), wallet.GetAddress().Hex())
+	}
+
+	if _, err := NewWalletFromKeystoreJSON(keyJSON, "wrong passphrase"); err == nil {
+		t.Error("NewWalletFromKeystoreJSON accepted a wrong passphrase")
+	}
+	if _, err := NewWalletFromKeystoreJSON([]byte("{}"), "passphrase"); err == nil {
+		t.Error("NewWalletFromKeystoreJSON accepted an empty keystore")
+	}
+}
+
+func TestSaveKeystore(t *testing.T) {
+	lightScrypt(t)
+
+	path := filepath.Join(t.TempDir(), "key.json")
+	wallet, err := NewRandomKeystore(path, "passphrase")
+	if err != nil {
+		t.Fatalf("NewRandomKeystore failed: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	loaded, err := NewWalletFromKeystore(path, "passphrase")
+	if err != nil {
+		t.Fatalf("NewWalletFromKeystore failed: %v", err)
+	}
+	if loaded.GetAddress() != wallet.GetAddress() {
+		t.Errorf("loaded %s, want %s", loaded.GetAddress().Hex(), wallet.GetAddress().Hex())
+	}
+
+	// An existing keystore is never overwritten
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewRandomWallet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := other.SaveKeystore(path, "passphrase"); err == nil {
+		t.Error("SaveKeystore overwrote an existing file")
+	}
+	if after, _ := os.ReadFile(path); string(after) != string(saved) {
+		t.Error("the existing keystore changed")
+	}
+
+	if _, err := NewWalletFromKeystore(filepath.Join(t.TempDir(), "missing.json"), "passphrase"); err == nil {
+		t.Error("NewWalletFromKeystore read a missing file")
+	}
+}
@@ -0,0 +1,309 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// Errors returned by HeaderVerifier
+var (
+	ErrMissingAuthHeaders = errors.New("missing authentication headers")
+	ErrStaleTimestamp     = errors.New("timestamp outside of the allowed window")
+	ErrInvalidSignature   = errors.New("invalid signature")
+	ErrUnknownAPIKey      = errors.New("unknown API key")
+	ErrAuthLevel          = errors.New("authentication level not accepted")
+	ErrBodyTooLarge       = errors.New("request body too large")
+)
+
+// defaultMaxBodyBytes is the default limit of the body read for L2 signatures
+const defaultMaxBodyBytes = 1 << 20
+
+// AuthLevel is the level of Polymarket authentication of a request
+type AuthLevel int
+
+const (
+	// AuthAny accepts L1 or L2 headers
+	AuthAny AuthLevel = iota
+	// AuthL1 is EIP-712 signed by the wallet (POLY_NONCE)
+	AuthL1
+	// AuthL2 is HMAC signed with an API key secret (POLY_API_KEY)
+	AuthL2
+)
+
+// APICredential is the server-side record of an API key
+type APICredential struct {
+	Address    string
+	Secret     string
+	Passphrase string
+}
+
+// CredentialLookup finds the credential of an API key for L2 verification
+type CredentialLookup interface {
+	// LookupAPIKey returns the credential of an API key, or nil if it is unknown
+	LookupAPIKey(ctx context.Context, apiKey string) (*APICredential, error)
+}
+
+// StaticCredentials is a CredentialLookup backed by a map keyed by API key
+type StaticCredentials map[string]APICredential
+
+// LookupAPIKey returns the credential of an API key, or nil if it is unknown
+func (s StaticCredentials) LookupAPIKey(ctx context.Context, apiKey string) (*APICredential, error) {
+	cred, ok := s[apiKey]
+	if !ok {
+		return nil, nil
+	}
+	return &cred, nil
+}
+
+// AuthInfo describes an authenticated request
+type AuthInfo struct {
+	Address common.Address
+	Level   AuthLevel
+	// APIKey is set for L2 requests
+	APIKey string
+	// Nonce is set for L1 requests
+	Nonce uint64
+}
+
+// VerifierOptions configures a HeaderVerifier
+type VerifierOptions struct {
+	// Chain the L1 signatures are made for
+	ChainID types.Chain
+
+	// Credentials of the API keys, required to accept L2 headers
+	Credentials CredentialLookup
+
+	// Level of authentication required (default AuthAny)
+	Level AuthLevel
+
+	// MaxSkew is how far a timestamp may be from the current time (default 30s)
+	MaxSkew time.Duration
+
+	// Now returns the current time (default time.Now)
+	Now func() time.Time
+
+	// MaxBodyBytes is the largest body read to verify an L2 signature
+	// (default 1 MiB). Larger requests are rejected with ErrBodyTooLarge.
+	MaxBodyBytes int64
+
+	// OnError writes the response for rejected requests (default 401 with a JSON error)
+	OnError func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HeaderVerifier validates the POLY_* authentication headers of incoming requests
+type HeaderVerifier struct {
+	options VerifierOptions
+}
+
+// NewHeaderVerifier creates a header verifier
+func NewHeaderVerifier(options *VerifierOptions) (*HeaderVerifier, error) {
+	if options == nil {
+		return nil, fmt.Errorf("verifier options are required")
+	}
+
+	opts := *options
+	if opts.Level != AuthL1 && opts.Credentials == nil {
+		return nil, fmt.Errorf("credentials lookup is required to verify L2 headers")
+	}
+	if opts.MaxSkew <= 0 {
+		opts.MaxSkew = 30 * time.Second
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = defaultMaxBodyBytes
+	}
+	if opts.OnError == nil {
+		opts.OnError = writeAuthError
+	}
+
+	return &HeaderVerifier{options: opts}, nil
+}
+
+// Middleware rejects requests without valid headers and adds the AuthInfo of
+// accepted requests to their context
+func (v *HeaderVerifier) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		info, err := v.VerifyRequest(r)
+		if err != nil {
+			v.options.OnError(w, r, err)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(ContextWithAuth(r.Context(), info)))
+	})
+}
+
+// VerifyRequest validates the headers of a request. The body is read for L2
+// signatures and restored for the next handler.
+func (v *HeaderVerifier) VerifyRequest(r *http.Request) (*AuthInfo, error) {
+	level := AuthL1
+	if r.Header.Get("POLY_API_KEY") != "" {
+		level = AuthL2
+	}
+
+	if v.options.Level != AuthAny && v.options.Level != level {
+		return nil, ErrAuthLevel
+	}
+
+	if level == AuthL2 {
+		return v.verifyL2(r)
+	}
+	return v.verifyL1(r)
+}
+
+func (v *HeaderVerifier) verifyL1(r *http.Request) (*AuthInfo, error) {
+	address := r.Header.Get("POLY_ADDRESS")
+	signature := r.Header.Get("POLY_SIGNATURE")
+	timestampHeader := r.Header.Get("POLY_TIMESTAMP")
+	nonceHeader := r.Header.Get("POLY_NONCE")
+	if address == "" || signature == "" || timestampHeader == "" || nonceHeader == "" {
+		return nil, ErrMissingAuthHeaders
+	}
+
+	timestamp, err := v.checkTimestamp(timestampHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := strconv.ParseUint(nonceHeader, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nonce %q", nonceHeader)
+	}
+
+	valid, err := VerifyEIP712Signature(address, signature, timestamp, nonce, v.options.ChainID)
+	if err != nil || !valid {
+		return nil, ErrInvalidSignature
+	}
+
+	return &AuthInfo{
+		Address: common.HexToAddress(address),
+		Level:   AuthL1,
+		Nonce:   nonce,
+	}, nil
+}
+
+func (v *HeaderVerifier) verifyL2(r *http.Request) (*AuthInfo, error) {
+	address := r.Header.Get("POLY_ADDRESS")
+	signature := r.Header.Get("POLY_SIGNATURE")
+	timestampHeader := r.Header.Get("POLY_TIMESTAMP")
+	apiKey := r.Header.Get("POLY_API_KEY")
+	passphrase := r.Header.Get("POLY_PASSPHRASE")
+	if address == "" || signature == "" || timestampHeader == "" || passphrase == "" {
+		return nil, ErrMissingAuthHeaders
+	}
+
+	timestamp, err := v.checkTimestamp(timestampHeader)
+	if err != nil {
+		return nil, err
+	}
+	if r.ContentLength > v.options.MaxBodyBytes {
+		return nil, ErrBodyTooLarge
+	}
+
+	cred, err := v.options.Credentials.LookupAPIKey(r.Context(), apiKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up API key: %w", err)
+	}
+	if cred == nil {
+		return nil, ErrUnknownAPIKey
+	}
+
+	if subtle.ConstantTimeCompare([]byte(passphrase), []byte(cred.Passphrase)) != 1 {
+		return nil, ErrInvalidSignature
+	}
+	if !common.IsHexAddress(address) || common.HexToAddress(address) != common.HexToAddress(cred.Address) {
+		return nil, ErrInvalidSignature
+	}
+
+	var body *string
+	if r.Body != nil {
+		// Bound the body so a client cannot make the server buffer it all
+		data, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, v.options.MaxBodyBytes))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return nil, ErrBodyTooLarge
+			}
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(data))
+
+		if len(data) > 0 {
+			bodyStr := string(data)
+			body = &bodyStr
+		}
+	}
+
+	// Clients sign the path without the query string
+	if !VerifyHmacSignature(cred.Secret, timestamp, r.Method, r.URL.Path, body, signature) {
+		return nil, ErrInvalidSignature
+	}
+
+	return &AuthInfo{
+		Address: common.HexToAddress(cred.Address),
+		Level:   AuthL2,
+		APIKey:  apiKey,
+	}, nil
+}
+
+// checkTimestamp parses a Unix timestamp and checks that it is fresh
+func (v *HeaderVerifier) checkTimestamp(header string) (int64, error) {
+	timestamp, err := strconv.ParseInt(header, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timestamp %q", header)
+	}
+
+	skew := v.options.Now().Sub(time.Unix(timestamp, 0))
+	if skew > v.options.MaxSkew || skew < -v.options.MaxSkew {
+		return 0, ErrStaleTimestamp
+	}
+
+	return timestamp, nil
+}
+
+func writeAuthError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusUnauthorized
+	if errors.Is(err, ErrBodyTooLarge) {
+		status = http.StatusRequestEntityTooLarge
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+type authContextKey struct{}
+
+// ContextWithAuth returns a context carrying the AuthInfo of a request
+func ContextWithAuth(ctx context.Context, info *AuthInfo) context.Context {
+	return context.WithValue(ctx, authContextKey{}, info)
+}
+
+// AuthFromContext returns the AuthInfo added by HeaderVerifier.Middleware
+func AuthFromContext(ctx context.Context) (*AuthInfo, bool) {
+	info, ok := ctx.Value(authContextKey{}).(*AuthInfo)
+	return info, ok
+}
+
+// AddressFromContext returns the authenticated address of a request
+func AddressFromContext(ctx context.Context) (common.Address, bool) {
+	info, ok := AuthFromContext(ctx)
+	if !ok {
+		return common.Address{}, false
+	}
+	return info.Address, true
+}
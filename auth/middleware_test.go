@@ -0,0 +1,270 @@
+package auth
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+var (
+	testNow   = time.Unix(1700000000, 0)
+	testCreds = &types.ApiKeyCreds{
+		Key:        "7c1a6f0e-6d4f-4c1e-9a55-3c8f1b2d9e10",
+		Secret:     "c2VjcmV0LXNlY3JldC1zZWNyZXQtc2VjcmV0LXNlY3JldA==",
+		Passphrase: "passphrase",
+	}
+)
+
+// newTestVerifier returns a verifier that knows testCreds for wallet
+func newTestVerifier(t *testing.T, wallet *Wallet, options VerifierOptions) *HeaderVerifier {
+	t.Helper()
+
+	options.ChainID = types.ChainPolygon
+	options.Now = func() time.Time { return testNow }
+	if options.Credentials == nil {
+		options.Credentials = StaticCredentials{
+			testCreds.Key: {Address: wallet.GetAddress().Hex(), Secret: testCreds.Secret, Passphrase: testCreds.Passphrase},
+		}
+	}
+
+	verifier, err := NewHeaderVerifier(&options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return verifier
+}
+
+// l1Request returns a request with L1 headers signed by signer at ts
+func l1Request(t *testing.T, signer Signer, ts int64) *http.Request {
+	t.Helper()
+
+	nonce := uint64(7)
+	headers, err := CreateL1Headers(signer, types.ChainPolygon, &nonce, &ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/auth/api-key", nil)
+	r.Header.Set("POLY_ADDRESS", headers.POLYAddress)
+	r.Header.Set("POLY_SIGNATURE", headers.POLYSignature)
+	r.Header.Set("POLY_TIMESTAMP", headers.POLYTimestamp)
+	r.Header.Set("POLY_NONCE", headers.POLYNonce)
+	return r
+}
+
+// l2Request returns a POST request with L2 headers signed with creds at ts
+func l2Request(t *testing.T, signer Signer, creds *types.ApiKeyCreds, body string, ts int64) *http.Request {
+	t.Helper()
+
+	headers, err := CreateL2Headers(signer, creds, &types.L2HeaderArgs{Method: http.MethodPost, RequestPath: "/order", Body: body}, &ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/order?debug=1", strings.NewReader(body))
+	r.Header.Set("POLY_ADDRESS", headers.POLYAddress)
+	r.Header.Set("POLY_SIGNATURE", headers.POLYSignature)
+	r.Header.Set("POLY_TIMESTAMP", headers.POLYTimestamp)
+	r.Header.Set("POLY_API_KEY", headers.POLYAPIKey)
+	r.Header.Set("POLY_PASSPHRASE", headers.POLYPassphrase)
+	return r
+}
+
+// echoHandler writes the authenticated address, level and body of a request
+var echoHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	info, ok := AuthFromContext(r.Context())
+	address, addressOK := AddressFromContext(r.Context())
+	if !ok || !addressOK || info.Address != address {
+		http.Error(w, "no auth info", http.StatusInternalServerError)
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	json.NewEncoder(w).Encode(map[string]any{
+		"address": address.Hex(),
+		"level":   info.Level,
+		"apiKey":  info.APIKey,
+		"nonce":   info.Nonce,
+		"body":    string(body),
+	})
+})
+
+func TestMiddleware(t *testing.T) {
+	wallet, err := NewWalletFromHex(testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewRandomWallet()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const body = `{"order":{"salt":"1"},"owner":"7c1a6f0e"}`
+	now := testNow.Unix()
+	wrongPassphrase := *testCreds
+	wrongPassphrase.Passphrase = "guess"
+	unknownKey := *testCreds
+	unknownKey.Key = "unknown"
+
+	tests := []struct {
+		name    string
+		options VerifierOptions
+		request func() *http.Request
+		status  int
+		err     error
+		want    map[string]any
+	}{
+		{
+			name:    "valid L1",
+			request: func() *http.Request { return l1Request(t, wallet, now) },
+			status:  http.StatusOK,
+			want:    map[string]any{"address": wallet.GetAddress().Hex(), "level": float64(AuthL1), "nonce": float64(7)},
+		},
+		{
+			name:    "valid L2",
+			request: func() *http.Request { return l2Request(t, wallet, testCreds, body, now-10) },
+			status:  http.StatusOK,
+			want:    map[string]any{"address": wallet.GetAddress().Hex(), "level": float64(AuthL2), "apiKey": testCreds.Key, "body": body},
+		},
+		{
+			name:    "stale L1 timestamp",
+			request: func() *http.Request { return l1Request(t, wallet, now-31) },
+			status:  http.StatusUnauthorized,
+			err:     ErrStaleTimestamp,
+		},
+		{
+			name:    "stale L2 timestamp",
+			request: func() *http.Request { return l2Request(t, wallet, testCreds, body, now+31) },
+			status:  http.StatusUnauthorized,
+			err:     ErrStaleTimestamp,
+		},
+		{
+			name: "bad HMAC",
+			request: func() *http.Request {
+				r := l2Request(t, wallet, testCreds, body, now)
+				r.Header.Set("POLY_SIGNATURE", BuildPolyHmacSignature(testCreds.Secret, now, http.MethodDelete, "/order", &[]string{body}[0]))
+				return r
+			},
+			status: http.StatusUnauthorized,
+			err:    ErrInvalidSignature,
+		},
+		{
+			name: "tampered body",
+			request: func() *http.Request {
+				r := l2Request(t, wallet, testCreds, body, now)
+				tampered := strings.Replace(body, `"1"`, `"2"`, 1)
+				r.Body = io.NopCloser(strings.NewReader(tampered))
+				r.ContentLength = int64(len(tampered))
+				return r
+			},
+			status: http.StatusUnauthorized,
+			err:    ErrInvalidSignature,
+		},
+		{
+			name:    "wrong passphrase",
+			request: func() *http.Request { return l2Request(t, wallet, &wrongPassphrase, body, now) },
+			status:  http.StatusUnauthorized,
+			err:     ErrInvalidSignature,
+		},
+		{
+			name:    "key of another address",
+			request: func() *http.Request { return l2Request(t, other, testCreds, body, now) },
+			status:  http.StatusUnauthorized,
+			err:     ErrInvalidSignature,
+		},
+		{
+			name:    "unknown key",
+			request: func() *http.Request { return l2Request(t, wallet, &unknownKey, body, now) },
+			status:  http.StatusUnauthorized,
+			err:     ErrUnknownAPIKey,
+		},
+		{
+			name: "L1 signed by another wallet",
+			request: func() *http.Request {
+				r := l1Request(t, other, now)
+				r.Header.Set("POLY_ADDRESS", wallet.GetAddress().Hex())
+				return r
+			},
+			status: http.StatusUnauthorized,
+			err:    ErrInvalidSignature,
+		},
+		{
+			name: "missing headers",
+			request: func() *http.Request {
+				r := l2Request(t, wallet, testCreds, body, now)
+				r.Header.Del("POLY_PASSPHRASE")
+				return r
+			},
+			status: http.StatusUnauthorized,
+			err:    ErrMissingAuthHeaders,
+		},
+		{
+			name:    "L2 when L1 is required",
+			options: VerifierOptions{Level: AuthL1},
+			request: func() *http.Request { return l2Request(t, wallet, testCreds, body, now) },
+			status:  http.StatusUnauthorized,
+			err:     ErrAuthLevel,
+		},
+		{
+			name:    "declared body too large",
+			options: VerifierOptions{MaxBodyBytes: 16},
+			request: func() *http.Request { return l2Request(t, wallet, testCreds, body, now) },
+			status:  http.StatusRequestEntityTooLarge,
+			err:     ErrBodyTooLarge,
+		},
+		{
+			name:    "streamed body too large",
+			options: VerifierOptions{MaxBodyBytes: 16},
+			request: func() *http.Request {
+				r := l2Request(t, wallet, testCreds, body, now)
+				r.ContentLength = -1
+				return r
+			},
+			status: http.StatusRequestEntityTooLarge,
+			err:    ErrBodyTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier := newTestVerifier(t, wallet, tt.options)
+			w := httptest.NewRecorder()
+			verifier.Middleware(echoHandler).ServeHTTP(w, tt.request())
+
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+
+			var got map[string]any
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("invalid response %s: %v", w.Body, err)
+			}
+			if tt.err != nil {
+				if got["error"] != tt.err.Error() {
+					t.Errorf("error = %v, want %v", got["error"], tt.err)
+				}
+				return
+			}
+			for key, want := range tt.want {
+				if got[key] != want {
+					t.Errorf("%s = %v, want %v", key, got[key], want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewHeaderVerifierRequiresCredentials(t *testing.T) {
+	if _, err := NewHeaderVerifier(&VerifierOptions{}); err == nil {
+		t.Error("NewHeaderVerifier accepted L2 verification without credentials")
+	}
+	if _, err := NewHeaderVerifier(&VerifierOptions{Level: AuthL1}); err != nil {
+		t.Errorf("NewHeaderVerifier failed for L1 only: %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// OrderTypes is the EIP-712 type definition of a CTF Exchange order
+var OrderTypes = map[string][]EIP712Type{
+	"Order": {
+		{Name: "salt", Type: "uint256"},
+		{Name: "maker", Type: "address"},
+		{Name: "signer", Type: "address"},
+		{Name: "taker", Type: "address"},
+		{Name: "tokenId", Type: "uint256"},
+		{Name: "makerAmount", Type: "uint256"},
+		{Name: "takerAmount", Type: "uint256"},
+		{Name: "expiration", Type: "uint256"},
+		{Name: "nonce", Type: "uint256"},
+		{Name: "feeRateBps", Type: "uint256"},
+		{Name: "side", Type: "uint8"},
+		{Name: "signatureType", Type: "uint8"},
+	},
+}
+
+// OrderSide returns the on-chain value of an order side (BUY = 0, SELL = 1)
+func OrderSide(side types.Side) (uint8, error) {
+	switch side {
+	case types.SideBuy:
+		return 0, nil
+	case types.SideSell:
+		return 1, nil
+	}
+	return 0, fmt.Errorf("invalid order side %q", side)
+}
+
+// OrderTypedData returns the typed data signed for an order on the given exchange contract
+func OrderTypedData(order *types.SignedOrder, chainID int64, exchangeAddress string) (TypedData, error) {
+	side, err := OrderSide(order.Side)
+	if err != nil {
+		return TypedData{}, err
+	}
+
+	if order.MakerAmount == nil || order.TakerAmount == nil {
+		return TypedData{}, fmt.Errorf("order amounts are required")
+	}
+
+	return TypedData{
+		Types:       OrderTypes,
+		PrimaryType: "Order",
+		Domain: EIP712Domain{
+			Name:              "Polymarket CTF Exchange",
+			Version:           "1",
+			ChainID:           chainID,
+			VerifyingContract: exchangeAddress,
+		},
+		Message: map[string]interface{}{
+			"salt":          order.Salt,
+			"maker":         order.Maker,
+			"signer":        order.Signer,
+			"taker":         order.Taker,
+			"tokenId":       order.TokenID,
+			"makerAmount":   order.MakerAmount,
+			"takerAmount":   order.TakerAmount,
+			"expiration":    order.Expiration,
+			"nonce":         order.Nonce,
+			"feeRateBps":    order.FeeRateBps,
+			"side":          side,
+			"signatureType": int(order.SignatureType),
+		},
+	}, nil
+}
+
+// SignOrder signs an order for the given exchange contract and sets its signature
+func SignOrder(signer Signer, order *types.SignedOrder, chainID int64, exchangeAddress string) error {
+	typedData, err := OrderTypedData(order, chainID, exchangeAddress)
+	if err != nil {
+		return err
+	}
+
+	signature, err := signer.SignTypedData(typedData)
+	if err != nil {
+		return fmt.Errorf("failed to sign order: %w", err)
+	}
+
+	order.Signature = signature
+	return nil
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// Init code hashes of the wallets deployed by the factories in types.ContractConfig
+var (
+	proxyWalletInitCodeHash = common.HexToHash("0xd21df8dc65880a8606f09fe0ce3df9b8869287ab0b058be05aa9e8af6330a00b")
+	safeWalletInitCodeHash  = common.HexToHash("0x2bce2127ff07fb632d16c8347c4ebf501f4841168bed00d9e6ef715ddb6fcecf")
+)
+
+// GetProxyWalletAddress computes the Polymarket proxy wallet address of an EOA.
+// The wallet does not need to be deployed yet.
+func GetProxyWalletAddress(eoa common.Address, chainID types.Chain) (common.Address, error) {
+	contracts, err := types.GetContractConfig(chainID)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return proxyWalletAddress(eoa, contracts)
+}
+
+// GetSafeWalletAddress computes the Polymarket Gnosis Safe address of an EOA.
+// The Safe does not need to be deployed yet.
+func GetSafeWalletAddress(eoa common.Address, chainID types.Chain) (common.Address, error) {
+	contracts, err := types.GetContractConfig(chainID)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return safeWalletAddress(eoa, contracts)
+}
+
+// GetFunderAddress returns the address holding the funds of an EOA for a
+// signature type: the EOA itself, its proxy wallet or its Safe
+func GetFunderAddress(eoa common.Address, chainID types.Chain, signatureType types.SignatureType) (common.Address, error) {
+	if signatureType == types.SignatureTypeEOA {
+		return eoa, nil
+	}
+
+	contracts, err := types.GetContractConfig(chainID)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return DeriveFunderAddress(eoa, contracts, signatureType)
+}
+
+// DeriveFunderAddress is GetFunderAddress with the factories of the given
+// contracts, e.g. on a local devnet
+func DeriveFunderAddress(eoa common.Address, contracts *types.ContractConfig, signatureType types.SignatureType) (common.Address, error) {
+	switch signatureType {
+	case types.SignatureTypeEOA:
+		return eoa, nil
+	case types.SignatureTypePolyProxy:
+		return proxyWalletAddress(eoa, contracts)
+	case types.SignatureTypePolyGnosisSafe:
+		return safeWalletAddress(eoa, contracts)
+	}
+	return common.Address{}, fmt.Errorf("invalid signature type %d", signatureType)
+}
+
+func proxyWalletAddress(eoa common.Address, contracts *types.ContractConfig) (common.Address, error) {
+	if contracts.ProxyFactory == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("proxy wallets are not supported: no proxy factory configured")
+	}
+
+	// The proxy factory salts with keccak256(abi.encodePacked(eoa))
+	salt := crypto.Keccak256Hash(eoa.Bytes())
+	return crypto.CreateAddress2(contracts.ProxyFactory, salt, proxyWalletInitCodeHash.Bytes()), nil
+}
+
+func safeWalletAddress(eoa common.Address, contracts *types.ContractConfig) (common.Address, error) {
+	if contracts.SafeFactory == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("safe wallets are not supported: no safe factory configured")
+	}
+
+	// The Safe factory salts with keccak256(abi.encode(eoa))
+	salt := crypto.Keccak256Hash(common.LeftPadBytes(eoa.Bytes(), 32))
+	return crypto.CreateAddress2(contracts.SafeFactory, salt, safeWalletInitCodeHash.Bytes()), nil
+}
@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// Wallets of the first Hardhat/Anvil account on Polygon
+var (
+	testEOA         = common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	testProxyWallet = common.HexToAddress("0x365f0CA36Ae1f641E02fE3B7743673da42A13A70")
+	testSafeWallet  = common.HexToAddress("0xd93B25cb943D14d0d34FBaF01Fc93a0f8b5F6E47")
+)
+
+// create2 computes a CREATE2 address from its definition,
+// keccak256(0xff ++ deployer ++ salt ++ keccak256(initCode))[12:], where the
+// factories use keccak256(saltData) as the salt
+func create2(deployer common.Address, saltData []byte, initCodeHash common.Hash) common.Address {
+	data := append([]byte{0xff}, deployer.Bytes()...)
+	data = append(data, crypto.Keccak256(saltData)...)
+	data = append(data, initCodeHash.Bytes()...)
+	return common.BytesToAddress(crypto.Keccak256(data)[12:])
+}
+
+func TestFunderAddress(t *testing.T) {
+	contracts, err := types.GetContractConfig(types.ChainPolygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The proxy factory salts with the packed 20-byte address and the Safe
+	// factory with the address padded to a word
+	if got := create2(contracts.ProxyFactory, testEOA.Bytes(), proxyWalletInitCodeHash); got != testProxyWallet {
+		t.Fatalf("proxy vector %s does not match CREATE2 %s", testProxyWallet.Hex(), got.Hex())
+	}
+	if got := create2(contracts.SafeFactory, common.LeftPadBytes(testEOA.Bytes(), 32), safeWalletInitCodeHash); got != testSafeWallet {
+		t.Fatalf("Safe vector %s does not match CREATE2 %s", testSafeWallet.Hex(), got.Hex())
+	}
+
+	tests := []struct {
+		signatureType types.SignatureType
+		want          common.Address
+	}{
+		{types.SignatureTypeEOA, testEOA},
+		{types.SignatureTypePolyProxy, testProxyWallet},
+		{types.SignatureTypePolyGnosisSafe, testSafeWallet},
+	}
+	for _, tt := range tests {
+		got, err := GetFunderAddress(testEOA, types.ChainPolygon, tt.signatureType)
+		if err != nil {
+			t.Fatalf("signature type %d: %v", tt.signatureType, err)
+		}
+		if got != tt.want {
+			t.Errorf("signature type %d: funder %s, want %s", tt.signatureType, got.Hex(), tt.want.Hex())
+		}
+	}
+
+	if proxy, err := GetProxyWalletAddress(testEOA, types.ChainPolygon); err != nil || proxy != testProxyWallet {
+		t.Errorf("GetProxyWalletAddress = %s, %v", proxy.Hex(), err)
+	}
+	if safe, err := GetSafeWalletAddress(testEOA, types.ChainPolygon); err != nil || safe != testSafeWallet {
+		t.Errorf("GetSafeWalletAddress = %s, %v", safe.Hex(), err)
+	}
+}
+
+func TestFunderAddressUnsupported(t *testing.T) {
+	if _, err := GetProxyWalletAddress(testEOA, types.ChainAmoy); err == nil {
+		t.Error("GetProxyWalletAddress succeeded without a proxy factory")
+	}
+	if _, err := GetFunderAddress(testEOA, types.ChainPolygon, types.SignatureType(7)); err == nil {
+		t.Error("GetFunderAddress accepted an invalid signature type")
+	}
+	if _, err := GetFunderAddress(testEOA, types.Chain(1), types.SignatureTypePolyProxy); err == nil {
+		t.Error("GetFunderAddress accepted an unknown chain")
+	}
+
+	// EOA accounts need no contracts
+	if funder, err := GetFunderAddress(testEOA, types.Chain(1), types.SignatureTypeEOA); err != nil || funder != testEOA {
+		t.Errorf("GetFunderAddress = %s, %v, want the EOA", funder.Hex(), err)
+	}
+}
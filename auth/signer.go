@@ -0,0 +1,231 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Signer signs on behalf of an Ethereum account. Signatures are hex encoded
+// with v normalized to 27/28. Wallet is the in-memory implementation;
+// hardware wallets, KMS keys and remote signers can implement it too.
+type Signer interface {
+	GetAddress() common.Address
+	SignHash(hash common.Hash) (string, error)
+	SignTypedData(typedData TypedData) (string, error)
+}
+
+// Remote signer endpoints served by RemoteSignerHandler and used by RemoteSigner
+const (
+	RemoteSignerAddressPath       = "/address"
+	RemoteSignerSignHashPath      = "/sign-hash"
+	RemoteSignerSignTypedDataPath = "/sign-typed-data"
+)
+
+// RemoteAddressResponse is the response of the address endpoint
+type RemoteAddressResponse struct {
+	Address string `json:"address"`
+}
+
+// RemoteSignHashRequest is the request of the sign-hash endpoint
+type RemoteSignHashRequest struct {
+	Hash string `json:"hash"`
+}
+
+// RemoteSignatureResponse is the response of the signing endpoints
+type RemoteSignatureResponse struct {
+	Signature string `json:"signature"`
+}
+
+// RemoteSignerOptions configures a RemoteSigner
+type RemoteSignerOptions struct {
+	// HTTP client used for requests (default: 30s timeout)
+	HTTPClient *http.Client
+
+	// Headers added to every request, e.g. an Authorization header
+	Headers map[string]string
+}
+
+// RemoteSigner is a Signer backed by an HTTP signing service. Every signature
+// is checked to recover to the signer address before it is returned.
+type RemoteSigner struct {
+	baseURL    string
+	httpClient *http.Client
+	headers    map[string]string
+	address    common.Address
+}
+
+// NewRemoteSigner creates a remote signer and fetches its address
+func NewRemoteSigner(baseURL string, options *RemoteSignerOptions) (*RemoteSigner, error) {
+	if options == nil {
+		options = &RemoteSignerOptions{}
+	}
+
+	httpClient := options.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	signer := &RemoteSigner{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: httpClient,
+		headers:    options.Headers,
+	}
+
+	var result RemoteAddressResponse
+	if err := signer.do("GET", RemoteSignerAddressPath, nil, &result); err != nil {
+		return nil, fmt.Errorf("failed to get signer address: %w", err)
+	}
+
+	if !common.IsHexAddress(result.Address) {
+		return nil, fmt.Errorf("invalid signer address %q", result.Address)
+	}
+	signer.address = common.HexToAddress(result.Address)
+
+	return signer, nil
+}
+
+// GetAddress returns the signer address
+func (s *RemoteSigner) GetAddress() common.Address {
+	return s.address
+}
+
+// SignHash asks the remote service to sign a hash
+func (s *RemoteSigner) SignHash(hash common.Hash) (string, error) {
+	var result RemoteSignatureResponse
+	if err := s.do("POST", RemoteSignerSignHashPath, RemoteSignHashRequest{Hash: hash.Hex()}, &result); err != nil {
+		return "", fmt.Errorf("failed to sign hash: %w", err)
+	}
+
+	return s.verify(hash, result.Signature)
+}
+
+// SignTypedData asks the remote service to sign EIP-712 typed data
+func (s *RemoteSigner) SignTypedData(typedData TypedData) (string, error) {
+	hash, err := HashTypedData(typedData)
+	if err != nil {
+		return "", fmt.Errorf("failed to get typed data hash: %w", err)
+	}
+
+	var result RemoteSignatureResponse
+	if err := s.do("POST", RemoteSignerSignTypedDataPath, typedData, &result); err != nil {
+		return "", fmt.Errorf("failed to sign typed data: %w", err)
+	}
+
+	return s.verify(hash, result.Signature)
+}
+
+// verify checks that a signature was made by the signer address and normalizes v
+func (s *RemoteSigner) verify(hash common.Hash, signature string) (string, error) {
+	recovered, err := RecoverAddress(hash, signature)
+	if err != nil {
+		return "", fmt.Errorf("invalid remote signature: %w", err)
+	}
+
+	if recovered != s.address {
+		return "", fmt.Errorf("remote signature is from %s, expected %s", recovered.Hex(), s.address.Hex())
+	}
+
+	return normalizeSignature(signature)
+}
+
+func (s *RemoteSigner) do(method, path string, data interface{}, result interface{}) error {
+	var body io.Reader
+	if data != nil {
+		jsonData, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request data: %w", err)
+		}
+		body = bytes.NewReader(jsonData)
+	}
+
+	req, err := http.NewRequest(method, s.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range s.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// RemoteSignerHandler serves a Signer over HTTP for RemoteSigner, e.g. as a
+// local stand-in for a KMS-backed signing service
+func RemoteSignerHandler(signer Signer) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+RemoteSignerAddressPath, func(w http.ResponseWriter, r *http.Request) {
+		writeSignerJSON(w, RemoteAddressResponse{Address: signer.GetAddress().Hex()})
+	})
+
+	mux.HandleFunc("POST "+RemoteSignerSignHashPath, func(w http.ResponseWriter, r *http.Request) {
+		var req RemoteSignHashRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		hash, err := toBytes(req.Hash)
+		if err != nil || len(hash) != common.HashLength {
+			http.Error(w, "invalid hash", http.StatusBadRequest)
+			return
+		}
+
+		signature, err := signer.SignHash(common.BytesToHash(hash))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeSignerJSON(w, RemoteSignatureResponse{Signature: signature})
+	})
+
+	mux.HandleFunc("POST "+RemoteSignerSignTypedDataPath, func(w http.ResponseWriter, r *http.Request) {
+		// Numbers are kept as json.Number so large integers stay exact
+		decoder := json.NewDecoder(r.Body)
+		decoder.UseNumber()
+
+		var typedData TypedData
+		if err := decoder.Decode(&typedData); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		signature, err := signer.SignTypedData(typedData)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		writeSignerJSON(w, RemoteSignatureResponse{Signature: signature})
+	})
+
+	return mux
+}
+
+func writeSignerJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,188 @@
+package auth
+
+import (
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// impostor claims the address of one account and signs with another
+type impostor struct {
+	Signer
+	address common.Address
+}
+
+func (s impostor) GetAddress() common.Address {
+	return s.address
+}
+
+// rawSigner returns signatures with v as 0/1, as some KMS services do
+type rawSigner struct {
+	Signer
+}
+
+func (s rawSigner) SignHash(hash common.Hash) (string, error) {
+	signature, err := s.Signer.SignHash(hash)
+	if err != nil {
+		return "", err
+	}
+	sig := hexutil.MustDecode(signature)
+	sig[64] -= 27
+	return hexutil.Encode(sig), nil
+}
+
+// newRemoteSigner serves signer and returns a RemoteSigner of it. Requests
+// without the bearer token are rejected.
+func newRemoteSigner(t *testing.T, signer Signer) *RemoteSigner {
+	t.Helper()
+
+	handler := RemoteSignerHandler(signer)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	remote, err := NewRemoteSigner(server.URL+"/", &RemoteSignerOptions{Headers: map[string]string{"Authorization": "Bearer token"}})
+	if err != nil {
+		t.Fatalf("NewRemoteSigner failed: %v", err)
+	}
+	return remote
+}
+
+func TestRemoteSigner(t *testing.T) {
+	wallet, err := NewWalletFromHex(testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	remote := newRemoteSigner(t, wallet)
+
+	if remote.GetAddress() != wallet.GetAddress() {
+		t.Errorf("address = %s, want %s", remote.GetAddress().Hex(), wallet.GetAddress().Hex())
+	}
+
+	hash := crypto.Keccak256Hash([]byte("hello"))
+	signature, err := remote.SignHash(hash)
+	if err != nil {
+		t.Fatalf("SignHash failed: %v", err)
+	}
+	if want, _ := wallet.SignHash(hash); signature != want {
+		t.Errorf("SignHash = %s, want %s", signature, want)
+	}
+
+	// Order typed data has integers too large for a float64
+	order := &types.SignedOrder{}
+	order.Salt = "479249096354"
+	order.Maker = wallet.GetAddress().Hex()
+	order.Signer = wallet.GetAddress().Hex()
+	order.Taker = "0x0000000000000000000000000000000000000000"
+	order.TokenID = "71321045679252212594626385532706912750332728571942532289631379312455583992563"
+	order.MakerAmount = big.NewInt(100000000)
+	order.TakerAmount = big.NewInt(50000000)
+	order.Expiration = "0"
+	order.Nonce = "0"
+	order.FeeRateBps = "0"
+	order.Side = types.SideBuy
+	orderData, err := OrderTypedData(order, 137, "0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, typedData := range map[string]TypedData{
+		"mail":      mailTypedData(),
+		"clob auth": ClobAuthTypedData(wallet.GetAddress().Hex(), 137, 10000000, 23),
+		"order":     orderData,
+	} {
+		t.Run(name, func(t *testing.T) {
+			signature, err := remote.SignTypedData(typedData)
+			if err != nil {
+				t.Fatalf("SignTypedData failed: %v", err)
+			}
+			want, err := wallet.SignTypedData(typedData)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if signature != want {
+				t.Errorf("SignTypedData = %s, want %s", signature, want)
+			}
+		})
+	}
+}
+
+func TestRemoteSignerNormalizesV(t *testing.T) {
+	wallet, err := NewWalletFromHex(testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	remote := newRemoteSigner(t, rawSigner{wallet})
+
+	hash := crypto.Keccak256Hash([]byte("hello"))
+	signature, err := remote.SignHash(hash)
+	if err != nil {
+		t.Fatalf("SignHash failed: %v", err)
+	}
+	if want, _ := wallet.SignHash(hash); signature != want {
+		t.Errorf("SignHash = %s, want %s", signature, want)
+	}
+}
+
+func TestRemoteSignerRejectsWrongKey(t *testing.T) {
+	wallet, err := NewWalletFromHex(testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewRandomWallet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	remote := newRemoteSigner(t, impostor{Signer: other, address: wallet.GetAddress()})
+
+	if _, err := remote.SignHash(crypto.Keccak256Hash([]byte("hello"))); err == nil || !strings.Contains(err.Error(), other.GetAddress().Hex()) {
+		t.Errorf("SignHash = %v, want a signature from %s rejected", err, other.GetAddress().Hex())
+	}
+	if _, err := remote.SignTypedData(mailTypedData()); err == nil {
+		t.Error("SignTypedData accepted a signature from another key")
+	}
+}
+
+func TestRemoteSignerErrors(t *testing.T) {
+	wallet, err := NewWalletFromHex(testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(RemoteSignerHandler(wallet))
+	defer server.Close()
+
+	if _, err := NewRemoteSigner(server.URL+"/missing", nil); err == nil {
+		t.Error("NewRemoteSigner succeeded without an address endpoint")
+	}
+
+	remote, err := NewRemoteSigner(server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	typedData := mailTypedData()
+	typedData.PrimaryType = "Unknown"
+	if _, err := remote.SignTypedData(typedData); err == nil {
+		t.Error("SignTypedData accepted an unknown primary type")
+	}
+
+	resp, err := http.Post(server.URL+RemoteSignerSignHashPath, "application/json", strings.NewReader(`{"hash":"0x1234"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("short hash: HTTP %d, want 400", resp.StatusCode)
+	}
+}
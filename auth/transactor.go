@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// SignTransaction signs an Ethereum transaction with a Signer
+func SignTransaction(signer Signer, tx *ethtypes.Transaction, chainID *big.Int) (*ethtypes.Transaction, error) {
+	txSigner := ethtypes.LatestSignerForChainID(chainID)
+
+	signature, err := signer.SignHash(txSigner.Hash(tx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	sig, err := hexutil.Decode(signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode signature: %w", err)
+	}
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("signature must be 65 bytes long")
+	}
+
+	// Transactions take the recovery id as 0/1
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+
+	return tx.WithSignature(txSigner, sig)
+}
+
+// NewTransactOpts returns go-ethereum transact options that sign with a Signer
+func NewTransactOpts(signer Signer, chainID *big.Int) *bind.TransactOpts {
+	from := signer.GetAddress()
+
+	return &bind.TransactOpts{
+		From: from,
+		Signer: func(address common.Address, tx *ethtypes.Transaction) (*ethtypes.Transaction, error) {
+			if address != from {
+				return nil, errors.New("not authorized to sign this account")
+			}
+			return SignTransaction(signer, tx, chainID)
+		},
+	}
+}
@@ -57,26 +57,22 @@ func (w *Wallet) GetPrivateKey() *ecdsa.PrivateKey {
 // SignMessage signs a message using the wallet's private key
 func (w *Wallet) SignMessage(message []byte) (string, error) {
 	hash := crypto.Keccak256Hash(message)
-	signature, err := crypto.Sign(hash.Bytes(), w.privateKey)
+	signature, err := signHash(w.privateKey, hash)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign message: %w", err)
 	}
 
-	// Convert to hex string
-	signatureHex := hexutil.Encode(signature)
-	return signatureHex, nil
+	return signature, nil
 }
 
 // SignHash signs a hash using the wallet's private key
 func (w *Wallet) SignHash(hash common.Hash) (string, error) {
-	signature, err := crypto.Sign(hash.Bytes(), w.privateKey)
-	if err != nil {
-		return "", fmt.Errorf("failed to sign hash: %w", err)
-	}
+	return signHash(w.privateKey, hash)
+}
 
-	// Convert to hex string
-	signatureHex := hexutil.Encode(signature)
-	return signatureHex, nil
+// SignTypedData signs EIP-712 typed data using the wallet's private key
+func (w *Wallet) SignTypedData(typedData TypedData) (string, error) {
+	return SignTypedData(w.privateKey, typedData)
 }
 
 // RecoverAddressFromMessage recovers an address from a signature and message
@@ -93,9 +89,9 @@ func RecoverAddressFromMessage(message []byte, signature string) (common.Address
 	// Compute message hash
 	hash := crypto.Keccak256Hash(message)
 
-	// Adjust v value if needed (go-ethereum expects 27 or 28)
-	if sig[64] != 27 && sig[64] != 28 {
-		sig[64] += 27
+	// crypto.SigToPub expects a recovery id of 0 or 1
+	if sig[64] >= 27 {
+		sig[64] -= 27
 	}
 
 	pubkey, err := crypto.SigToPub(hash.Bytes(), sig)
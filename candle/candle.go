@@ -0,0 +1,382 @@
+package candle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lixvyang/polymarket-sdk-go/data"
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// ErrOutOfOrder is returned for trades older than the bar currently being built
+var ErrOutOfOrder = errors.New("trade is older than the current candle")
+
+// Candle represents an OHLCV bar of a single asset
+type Candle struct {
+	AssetID string        `json:"assetId"`
+	Start   time.Time     `json:"start"`
+	End     time.Time     `json:"end"`
+	Open    types.Decimal `json:"open"`
+	High    types.Decimal `json:"high"`
+	Low     types.Decimal `json:"low"`
+	Close   types.Decimal `json:"close"`
+	Volume  types.Decimal `json:"volume"`
+	Trades  int           `json:"trades"`
+	// Filled marks bars created by gap filling, which carry the previous close
+	Filled bool `json:"filled"`
+}
+
+// BuilderOptions configures a Builder
+type BuilderOptions struct {
+	// Bar interval, e.g. time.Minute
+	Interval time.Duration
+
+	// Emit flat, zero-volume bars for intervals without trades
+	FillGaps bool
+
+	// Capacity of the candle channel (default 256)
+	BufferSize int
+}
+
+// assetState tracks the open bar of a single asset
+type assetState struct {
+	bar       *Candle
+	lastClose types.Decimal
+	// nextStart is the start of the first bar not yet emitted
+	nextStart time.Time
+}
+
+// aggregator turns trades into candles and hands completed bars to emit
+type aggregator struct {
+	interval time.Duration
+	fillGaps bool
+	assets   map[string]*assetState
+	emit     func(Candle)
+}
+
+func newAggregator(interval time.Duration, fillGaps bool, emit func(Candle)) *aggregator {
+	return &aggregator{
+		interval: interval,
+		fillGaps: fillGaps,
+		assets:   make(map[string]*assetState),
+		emit:     emit,
+	}
+}
+
+func (a *aggregator) add(assetID string, ts time.Time, price, size types.Decimal) error {
+	start := ts.Truncate(a.interval)
+
+	state, ok := a.assets[assetID]
+	if !ok {
+		state = &assetState{}
+		a.assets[assetID] = state
+	}
+
+	if state.bar != nil && start.Before(state.bar.Start) {
+		return ErrOutOfOrder
+	}
+	if state.bar == nil && start.Before(state.nextStart) {
+		return ErrOutOfOrder
+	}
+
+	if state.bar != nil && start.After(state.bar.Start) {
+		a.closeBar(state)
+	}
+
+	if state.bar == nil {
+		a.fill(assetID, state, start)
+		state.bar = &Candle{
+			AssetID: assetID,
+			Start:   start,
+			End:     start.Add(a.interval),
+			Open:    price,
+			High:    price,
+			Low:     price,
+		}
+	}
+
+	bar := state.bar
+	if price.GreaterThan(bar.High) {
+		bar.High = price
+	}
+	if price.LessThan(bar.Low) {
+		bar.Low = price
+	}
+	bar.Close = price
+	bar.Volume = bar.Volume.Add(size)
+	bar.Trades++
+
+	return nil
+}
+
+// advance emits every bar that ends at or before now, gap filling up to now
+func (a *aggregator) advance(now time.Time) {
+	for assetID, state := range a.assets {
+		if state.bar != nil && !state.bar.End.After(now) {
+			a.closeBar(state)
+		}
+		if state.bar == nil {
+			a.fill(assetID, state, now.Truncate(a.interval))
+		}
+	}
+}
+
+// flush emits every open bar
+func (a *aggregator) flush() {
+	for _, state := range a.assets {
+		if state.bar != nil {
+			a.closeBar(state)
+		}
+	}
+}
+
+func (a *aggregator) closeBar(state *assetState) {
+	a.emit(*state.bar)
+	state.lastClose = state.bar.Close
+	state.nextStart = state.bar.End
+	state.bar = nil
+}
+
+// fill emits flat bars from the last emitted bar up to (excluding) until
+func (a *aggregator) fill(assetID string, state *assetState, until time.Time) {
+	if state.nextStart.IsZero() {
+		return
+	}
+
+	if a.fillGaps {
+		for start := state.nextStart; start.Before(until); start = start.Add(a.interval) {
+			a.emit(Candle{
+				AssetID: assetID,
+				Start:   start,
+				End:     start.Add(a.interval),
+				Open:    state.lastClose,
+				High:    state.lastClose,
+				Low:     state.lastClose,
+				Close:   state.lastClose,
+				Filled:  true,
+			})
+		}
+	}
+
+	if until.After(state.nextStart) {
+		state.nextStart = until
+	}
+}
+
+// Builder aggregates a live trade stream into candles and publishes completed
+// bars on a channel
+type Builder struct {
+	mu      sync.Mutex
+	agg     *aggregator
+	pending []Candle // completed bars not yet sent
+	closed  bool
+
+	// sendMu keeps bars in order while they are sent without holding mu
+	sendMu sync.Mutex
+	out    chan Candle
+}
+
+// NewBuilder creates a new candle builder
+func NewBuilder(options *BuilderOptions) (*Builder, error) {
+	if options == nil || options.Interval <= 0 {
+		return nil, fmt.Errorf("candle interval must be positive")
+	}
+
+	bufferSize := options.BufferSize
+	if bufferSize <= 0 {
+		bufferSize = 256
+	}
+
+	b := &Builder{
+		out: make(chan Candle, bufferSize),
+	}
+	b.agg = newAggregator(options.Interval, options.FillGaps, func(c Candle) {
+		b.pending = append(b.pending, c)
+	})
+
+	return b, nil
+}
+
+// Candles returns the channel of completed bars. Sends block when the buffer
+// is full, so the channel must be drained, but they do not block other calls
+// from updating the open bars. It is closed by Close.
+func (b *Builder) Candles() <-chan Candle {
+	return b.out
+}
+
+// AddTrade adds a single trade
+func (b *Builder) AddTrade(assetID string, ts time.Time, price, size types.Decimal) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return fmt.Errorf("candle builder is closed")
+	}
+
+	err := b.agg.add(assetID, ts, price, size)
+	b.unlockAndSend(false)
+	return err
+}
+
+// AddLastTradePrice adds a trade received on the market WebSocket channel
+func (b *Builder) AddLastTradePrice(msg *types.LastTradePriceMessage) error {
+	ts, err := parseLastTradePrice(msg)
+	if err != nil {
+		return err
+	}
+
+	return b.AddTrade(msg.AssetID, ts, msg.Price, msg.Size)
+}
+
+// AddDataTrade adds a trade from the Data API
+func (b *Builder) AddDataTrade(trade data.DataTrade) error {
+	return b.AddTrade(trade.AssetID, time.Unix(trade.Timestamp, 0), trade.Price, trade.Size)
+}
+
+// Advance emits every bar that ended at or before now. Call it periodically
+// so bars are published even when no further trades arrive.
+func (b *Builder) Advance(now time.Time) {
+	b.mu.Lock()
+	if !b.closed {
+		b.agg.advance(now)
+	}
+	b.unlockAndSend(false)
+}
+
+// Close emits the bars still open and closes the candle channel
+func (b *Builder) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+
+	b.agg.flush()
+	b.closed = true
+	b.unlockAndSend(true)
+}
+
+// unlockAndSend releases mu, which must be held, and sends the bars completed
+// while it was held, closing the channel afterwards if closeOut is set.
+// sendMu is taken before mu is released, so bars are sent in the order they
+// were completed.
+func (b *Builder) unlockAndSend(closeOut bool) {
+	pending := b.pending
+	b.pending = nil
+	if len(pending) == 0 && !closeOut {
+		b.mu.Unlock()
+		return
+	}
+
+	b.sendMu.Lock()
+	defer b.sendMu.Unlock()
+	b.mu.Unlock()
+
+	for _, c := range pending {
+		b.out <- c
+	}
+	if closeOut {
+		close(b.out)
+	}
+}
+
+// BuildCandles aggregates Data API trade history into candles, oldest first
+func BuildCandles(trades []data.DataTrade, interval time.Duration, fillGaps bool) ([]Candle, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("candle interval must be positive")
+	}
+
+	// The Data API returns newest trades first
+	sorted := append([]data.DataTrade(nil), trades...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp < sorted[j].Timestamp
+	})
+
+	var candles []Candle
+	agg := newAggregator(interval, fillGaps, func(c Candle) {
+		candles = append(candles, c)
+	})
+
+	for _, trade := range sorted {
+		if err := agg.add(trade.AssetID, time.Unix(trade.Timestamp, 0), trade.Price, trade.Size); err != nil {
+			return nil, err
+		}
+	}
+	agg.flush()
+
+	sortCandles(candles)
+	return candles, nil
+}
+
+// FromPriceHistory aggregates CLOB price history points into candles. Price
+// history carries no sizes, so Volume is always zero.
+func FromPriceHistory(assetID string, points []types.MarketPrice, interval time.Duration, fillGaps bool) ([]Candle, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("candle interval must be positive")
+	}
+
+	sorted := append([]types.MarketPrice(nil), points...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].T < sorted[j].T
+	})
+
+	var candles []Candle
+	agg := newAggregator(interval, fillGaps, func(c Candle) {
+		candles = append(candles, c)
+	})
+
+	for _, point := range sorted {
+		if err := agg.add(assetID, time.Unix(point.T, 0), point.P, types.Decimal{}); err != nil {
+			return nil, err
+		}
+	}
+	agg.flush()
+
+	return candles, nil
+}
+
+// ParsePriceHistory extracts the points of a ClobClient.GetPricesHistory response
+func ParsePriceHistory(response interface{}) ([]types.MarketPrice, error) {
+	raw, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal price history: %w", err)
+	}
+
+	var result struct {
+		History []types.MarketPrice `json:"history"`
+	}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal price history: %w", err)
+	}
+
+	return result.History, nil
+}
+
+func parseLastTradePrice(msg *types.LastTradePriceMessage) (time.Time, error) {
+	ms, err := strconv.ParseInt(msg.Timestamp, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q: %w", msg.Timestamp, err)
+	}
+	if msg.Price.Sign() <= 0 {
+		return time.Time{}, fmt.Errorf("invalid price %s", msg.Price)
+	}
+	if msg.Size.Sign() <= 0 {
+		return time.Time{}, fmt.Errorf("invalid size %s", msg.Size)
+	}
+
+	return time.UnixMilli(ms), nil
+}
+
+// sortCandles orders candles by start time, then asset ID
+func sortCandles(candles []Candle) {
+	sort.SliceStable(candles, func(i, j int) bool {
+		if !candles[i].Start.Equal(candles[j].Start) {
+			return candles[i].Start.Before(candles[j].Start)
+		}
+		return candles[i].AssetID < candles[j].AssetID
+	})
+}
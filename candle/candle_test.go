@@ -0,0 +1,343 @@
+package candle
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lixvyang/polymarket-sdk-go/data"
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// base is a minute boundary
+const base int64 = 1699999980
+
+func trade(assetID string, offset int64, price, size string) data.DataTrade {
+	return data.DataTrade{
+		AssetID:   assetID,
+		Timestamp: base + offset,
+		Price:     types.MustDecimal(price),
+		Size:      types.MustDecimal(size),
+	}
+}
+
+// describe formats a candle as "asset offset open/high/low/close volume trades",
+// with a trailing "filled" for gap-filled bars
+func describe(c Candle) string {
+	s := fmt.Sprintf("%s %d %s/%s/%s/%s %s %d", c.AssetID, c.Start.Unix()-base,
+		c.Open, c.High, c.Low, c.Close, c.Volume, c.Trades)
+	if c.End.Sub(c.Start) != time.Minute {
+		s += fmt.Sprintf(" length %s", c.End.Sub(c.Start))
+	}
+	if c.Filled {
+		s += " filled"
+	}
+	return s
+}
+
+func TestBuildCandles(t *testing.T) {
+	tests := []struct {
+		name     string
+		trades   []data.DataTrade
+		fillGaps bool
+		want     []string
+	}{
+		{
+			name: "ohlcv",
+			trades: []data.DataTrade{
+				trade("a", 5, "0.5", "10"),
+				trade("a", 20, "0.55", "5"),
+				trade("a", 30, "0.45", "2.5"),
+				trade("a", 50, "0.52", "3"),
+			},
+			want: []string{"a 0 0.5/0.55/0.45/0.52 20.5 4"},
+		},
+		{
+			name: "interval alignment",
+			trades: []data.DataTrade{
+				trade("a", 0, "0.1", "1"),
+				trade("a", 59, "0.2", "1"),
+				trade("a", 60, "0.3", "1"),
+				trade("a", 119, "0.4", "1"),
+			},
+			want: []string{
+				"a 0 0.1/0.2/0.1/0.2 2 2",
+				"a 60 0.3/0.4/0.3/0.4 2 2",
+			},
+		},
+		{
+			name: "gaps filled",
+			trades: []data.DataTrade{
+				trade("a", 10, "0.4", "1"),
+				trade("a", 190, "0.6", "2"),
+			},
+			fillGaps: true,
+			want: []string{
+				"a 0 0.4/0.4/0.4/0.4 1 1",
+				"a 60 0.4/0.4/0.4/0.4 0 0 filled",
+				"a 120 0.4/0.4/0.4/0.4 0 0 filled",
+				"a 180 0.6/0.6/0.6/0.6 2 1",
+			},
+		},
+		{
+			name: "gaps not filled",
+			trades: []data.DataTrade{
+				trade("a", 10, "0.4", "1"),
+				trade("a", 190, "0.6", "2"),
+			},
+			want: []string{
+				"a 0 0.4/0.4/0.4/0.4 1 1",
+				"a 180 0.6/0.6/0.6/0.6 2 1",
+			},
+		},
+		{
+			name: "newest first",
+			trades: []data.DataTrade{
+				trade("a", 70, "0.3", "1"),
+				trade("a", 40, "0.2", "1"),
+				trade("a", 10, "0.1", "1"),
+			},
+			want: []string{
+				"a 0 0.1/0.2/0.1/0.2 2 2",
+				"a 60 0.3/0.3/0.3/0.3 1 1",
+			},
+		},
+		{
+			name: "out of order",
+			trades: []data.DataTrade{
+				trade("a", 10, "0.1", "1"),
+				trade("a", 130, "0.5", "1"),
+				trade("a", 5, "0.3", "1"),
+				trade("a", 50, "0.2", "1"),
+			},
+			want: []string{
+				"a 0 0.3/0.3/0.1/0.2 3 3",
+				"a 120 0.5/0.5/0.5/0.5 1 1",
+			},
+		},
+		{
+			name: "assets are separate",
+			trades: []data.DataTrade{
+				trade("b", 10, "0.7", "1"),
+				trade("a", 20, "0.3", "1"),
+				trade("b", 70, "0.8", "1"),
+				trade("a", 130, "0.4", "1"),
+			},
+			fillGaps: true,
+			want: []string{
+				"a 0 0.3/0.3/0.3/0.3 1 1",
+				"b 0 0.7/0.7/0.7/0.7 1 1",
+				"a 60 0.3/0.3/0.3/0.3 0 0 filled",
+				"b 60 0.8/0.8/0.8/0.8 1 1",
+				"a 120 0.4/0.4/0.4/0.4 1 1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candles, err := BuildCandles(tt.trades, time.Minute, tt.fillGaps)
+			if err != nil {
+				t.Fatalf("BuildCandles failed: %v", err)
+			}
+
+			if len(candles) != len(tt.want) {
+				t.Errorf("got %d candles, want %d", len(candles), len(tt.want))
+			}
+			for i, c := range candles {
+				if i < len(tt.want) && describe(c) != tt.want[i] {
+					t.Errorf("candle %d = %s, want %s", i, describe(c), tt.want[i])
+				}
+			}
+		})
+	}
+
+	if _, err := BuildCandles(nil, 0, false); err == nil {
+		t.Error("BuildCandles accepted a zero interval")
+	}
+}
+
+// receive returns the next candle, failing the test if none is sent
+func receive(t *testing.T, b *Builder) Candle {
+	t.Helper()
+
+	select {
+	case c, ok := <-b.Candles():
+		if !ok {
+			t.Fatal("candle channel closed")
+		}
+		return c
+	case <-time.After(time.Second):
+		t.Fatal("no candle sent")
+	}
+	return Candle{}
+}
+
+// assertNone fails the test if a candle is waiting on the channel
+func assertNone(t *testing.T, b *Builder) {
+	t.Helper()
+
+	select {
+	case c := <-b.Candles():
+		t.Fatalf("unexpected candle %s", describe(c))
+	default:
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	b, err := NewBuilder(&BuilderOptions{Interval: time.Minute, FillGaps: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	add := func(offset int64, price string) {
+		t.Helper()
+		if err := b.AddTrade("a", time.Unix(base+offset, 0), types.MustDecimal(price), types.MustDecimal("1")); err != nil {
+			t.Fatalf("AddTrade failed: %v", err)
+		}
+	}
+
+	add(10, "0.1")
+	add(30, "0.3")
+	assertNone(t, b)
+
+	// A trade in the next interval completes the first bar
+	add(65, "0.2")
+	if got, want := describe(receive(t, b)), "a 0 0.1/0.3/0.1/0.3 2 2"; got != want {
+		t.Errorf("candle = %s, want %s", got, want)
+	}
+
+	err = b.AddTrade("a", time.Unix(base+59, 0), types.MustDecimal("0.5"), types.MustDecimal("1"))
+	if !errors.Is(err, ErrOutOfOrder) {
+		t.Errorf("AddTrade of an older trade = %v, want ErrOutOfOrder", err)
+	}
+
+	// Advance publishes the open bar once it ends, then fills the gap
+	b.Advance(time.Unix(base+119, 0))
+	assertNone(t, b)
+	b.Advance(time.Unix(base+240, 0))
+	for _, want := range []string{
+		"a 60 0.2/0.2/0.2/0.2 1 1",
+		"a 120 0.2/0.2/0.2/0.2 0 0 filled",
+		"a 180 0.2/0.2/0.2/0.2 0 0 filled",
+	} {
+		if got := describe(receive(t, b)); got != want {
+			t.Errorf("candle = %s, want %s", got, want)
+		}
+	}
+	assertNone(t, b)
+
+	// Close publishes the open bar and closes the channel
+	add(250, "0.4")
+	b.Close()
+	if got, want := describe(receive(t, b)), "a 240 0.4/0.4/0.4/0.4 1 1"; got != want {
+		t.Errorf("candle = %s, want %s", got, want)
+	}
+	if _, ok := <-b.Candles(); ok {
+		t.Error("candle channel still open after Close")
+	}
+
+	if err := b.AddTrade("a", time.Unix(base+300, 0), types.MustDecimal("0.5"), types.MustDecimal("1")); err == nil {
+		t.Error("AddTrade succeeded after Close")
+	}
+	b.Close()
+}
+
+func TestBuilderFullBuffer(t *testing.T) {
+	b, err := NewBuilder(&BuilderOptions{Interval: time.Minute, BufferSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	add := func(assetID string, offset int64) {
+		b.AddTrade(assetID, time.Unix(base+offset, 0), types.MustDecimal("0.5"), types.MustDecimal("1"))
+	}
+
+	// The first bar fills the buffer, so sending the second one blocks
+	add("a", 0)
+	add("a", 60)
+	blocked := make(chan struct{})
+	go func() {
+		defer close(blocked)
+		add("a", 120)
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	// A blocked send must not stop other trades from being added
+	added := make(chan struct{})
+	go func() {
+		defer close(added)
+		add("b", 130)
+	}()
+	select {
+	case <-added:
+	case <-time.After(time.Second):
+		t.Fatal("AddTrade blocked behind a full candle channel")
+	}
+
+	for _, want := range []int64{0, 60} {
+		if c := receive(t, b); c.Start.Unix()-base != want {
+			t.Errorf("candle starts at %d, want %d", c.Start.Unix()-base, want)
+		}
+	}
+	<-blocked
+}
+
+func TestNewBuilderInterval(t *testing.T) {
+	if _, err := NewBuilder(nil); err == nil {
+		t.Error("NewBuilder accepted nil options")
+	}
+	if _, err := NewBuilder(&BuilderOptions{Interval: -time.Second}); err == nil {
+		t.Error("NewBuilder accepted a negative interval")
+	}
+}
+
+func TestParseLastTradePrice(t *testing.T) {
+	valid := types.LastTradePriceMessage{
+		EventType: types.EventTypeLastTradePrice,
+		AssetID:   "a",
+		Market:    "0x1",
+		Price:     types.MustDecimal("0.456"),
+		Side:      types.SideBuy,
+		Size:      types.MustDecimal("219.217767"),
+		Timestamp: "1750428146322",
+	}
+
+	ts, err := parseLastTradePrice(&valid)
+	if err != nil {
+		t.Fatalf("parseLastTradePrice failed: %v", err)
+	}
+	if !ts.Equal(time.UnixMilli(1750428146322)) {
+		t.Errorf("parseLastTradePrice = %v", ts)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(msg *types.LastTradePriceMessage)
+	}{
+		{"missing timestamp", func(msg *types.LastTradePriceMessage) { msg.Timestamp = "" }},
+		{"fractional timestamp", func(msg *types.LastTradePriceMessage) { msg.Timestamp = "1750428146.322" }},
+		{"missing price", func(msg *types.LastTradePriceMessage) { msg.Price = types.Decimal{} }},
+		{"negative price", func(msg *types.LastTradePriceMessage) { msg.Price = types.MustDecimal("-0.5") }},
+		{"missing size", func(msg *types.LastTradePriceMessage) { msg.Size = types.Decimal{} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := valid
+			tt.modify(&msg)
+			if _, err := parseLastTradePrice(&msg); err == nil {
+				t.Error("parseLastTradePrice accepted invalid input")
+			}
+
+			b, err := NewBuilder(&BuilderOptions{Interval: time.Minute})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := b.AddLastTradePrice(&msg); err == nil {
+				t.Error("AddLastTradePrice accepted invalid input")
+			}
+		})
+	}
+}
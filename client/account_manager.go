@@ -0,0 +1,310 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lixvyang/polymarket-sdk-go/auth"
+	"github.com/lixvyang/polymarket-sdk-go/types"
+	"golang.org/x/time/rate"
+)
+
+// defaultMaxConcurrency is how many accounts ForEach runs at once by default
+const defaultMaxConcurrency = 8
+
+// AccountConfig describes one account of an AccountManager
+type AccountConfig struct {
+	// ID routes calls to the account
+	ID string
+
+	// PrivateKey or Signer of the account
+	PrivateKey string
+	Signer     auth.Signer
+
+	APIKey        *types.ApiKeyCreds
+	BuilderConfig *auth.BuilderConfig
+	SignatureType types.SignatureType
+	FunderAddress string
+}
+
+// AccountManagerOptions configures what the accounts of an AccountManager share
+type AccountManagerOptions struct {
+	Host          string
+	ChainID       types.Chain
+	GeoBlockToken string
+	UseServerTime bool
+
+	// ClockSyncInterval is how often the server time is measured when
+	// UseServerTime is set (default 5m)
+	ClockSyncInterval time.Duration
+
+	// Timeout of the shared HTTP client (default 30s), ignored if HTTPClient is set
+	Timeout    time.Duration
+	HTTPClient *http.Client
+
+	// RateLimit caps the requests per second of all accounts together, with
+	// bursts of up to RateBurst requests (default 1). Zero disables it.
+	RateLimit float64
+	RateBurst int
+
+	// MaxConcurrency is how many accounts ForEach runs at once (default 8)
+	MaxConcurrency int
+
+	// CredentialsStore persists the API credentials of all accounts
+	CredentialsStore CredentialsStore
+}
+
+// AccountOrder is an open order of an account
+type AccountOrder struct {
+	AccountID string
+	types.OpenOrder
+}
+
+// AccountManager holds clients for many accounts over a shared HTTP client,
+// clock sync and market cache
+type AccountManager struct {
+	options AccountManagerOptions
+	public  *ClobClient
+	market  *MarketCache
+	clock   *ClockSync
+
+	mu       sync.RWMutex
+	accounts map[string]*ClobClient
+}
+
+// NewAccountManager creates an account manager without accounts
+func NewAccountManager(options *AccountManagerOptions) (*AccountManager, error) {
+	if options == nil {
+		return nil, fmt.Errorf("account manager options are required")
+	}
+
+	opts := *options
+	if opts.HTTPClient == nil {
+		timeout := opts.Timeout
+		if timeout == 0 {
+			timeout = 30 * time.Second
+		}
+		opts.HTTPClient = &http.Client{Timeout: timeout}
+	}
+	if opts.RateLimit > 0 {
+		burst := opts.RateBurst
+		if burst <= 0 {
+			burst = 1
+		}
+		opts.HTTPClient = withRateLimit(opts.HTTPClient, rate.NewLimiter(rate.Limit(opts.RateLimit), burst))
+	}
+	if opts.MaxConcurrency <= 0 {
+		opts.MaxConcurrency = defaultMaxConcurrency
+	}
+
+	market := NewMarketCache()
+
+	// The public client reads the server time for the shared clock
+	public, err := NewClobClient(&ClientConfig{
+		Host:          opts.Host,
+		ChainID:       opts.ChainID,
+		GeoBlockToken: opts.GeoBlockToken,
+		HTTPClient:    opts.HTTPClient,
+		MarketCache:   market,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	m := &AccountManager{
+		options:  opts,
+		public:   public,
+		market:   market,
+		accounts: make(map[string]*ClobClient),
+	}
+
+	if opts.UseServerTime {
+		m.clock = NewClockSync(public.GetServerTime, &ClockSyncOptions{
+			Interval: opts.ClockSyncInterval,
+		})
+	}
+
+	return m, nil
+}
+
+// AddAccount creates the client of an account. API credentials that are not
+// configured are loaded, created or derived as in InitApiCreds.
+func (m *AccountManager) AddAccount(account *AccountConfig) (*ClobClient, error) {
+	if account == nil || account.ID == "" {
+		return nil, fmt.Errorf("account ID is required")
+	}
+	if account.Signer == nil && account.PrivateKey == "" {
+		return nil, fmt.Errorf("account %s: private key or signer is required", account.ID)
+	}
+
+	m.mu.RLock()
+	_, exists := m.accounts[account.ID]
+	m.mu.RUnlock()
+	if exists {
+		return nil, fmt.Errorf("account %s already exists", account.ID)
+	}
+
+	client, err := NewClobClient(&ClientConfig{
+		Host:             m.options.Host,
+		ChainID:          m.options.ChainID,
+		PrivateKey:       account.PrivateKey,
+		Signer:           account.Signer,
+		APIKey:           account.APIKey,
+		BuilderConfig:    account.BuilderConfig,
+		GeoBlockToken:    m.options.GeoBlockToken,
+		UseServerTime:    m.options.UseServerTime,
+		SignatureType:    account.SignatureType,
+		FunderAddress:    account.FunderAddress,
+		CredentialsStore: m.options.CredentialsStore,
+		HTTPClient:       m.options.HTTPClient,
+		ClockSync:        m.clock,
+		MarketCache:      m.market,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("account %s: %w", account.ID, err)
+	}
+
+	if _, err := client.InitApiCreds(); err != nil {
+		return nil, fmt.Errorf("account %s: %w", account.ID, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.accounts[account.ID]; exists {
+		return nil, fmt.Errorf("account %s already exists", account.ID)
+	}
+	m.accounts[account.ID] = client
+
+	return client, nil
+}
+
+// RemoveAccount removes an account and reports whether it existed
+func (m *AccountManager) RemoveAccount(id string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, ok := m.accounts[id]
+	delete(m.accounts, id)
+	return ok
+}
+
+// Account returns the client of an account
+func (m *AccountManager) Account(id string) (*ClobClient, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	client, ok := m.accounts[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown account %s", id)
+	}
+	return client, nil
+}
+
+// AccountIDs returns the IDs of all accounts in order
+func (m *AccountManager) AccountIDs() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ids := make([]string, 0, len(m.accounts))
+	for id := range m.accounts {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Public returns a client without signer for public endpoints
+func (m *AccountManager) Public() *ClobClient {
+	return m.public
+}
+
+// Do runs fn with the client of an account
+func (m *AccountManager) Do(id string, fn func(client *ClobClient) error) error {
+	client, err := m.Account(id)
+	if err != nil {
+		return err
+	}
+	return fn(client)
+}
+
+// ForEach runs fn concurrently for every account, at most MaxConcurrency at
+// a time. Errors are joined and prefixed with the account ID.
+func (m *AccountManager) ForEach(fn func(id string, client *ClobClient) error) error {
+	m.mu.RLock()
+	accounts := make(map[string]*ClobClient, len(m.accounts))
+	for id, client := range m.accounts {
+		accounts[id] = client
+	}
+	m.mu.RUnlock()
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+		sem  = make(chan struct{}, m.options.MaxConcurrency)
+	)
+	for id, client := range accounts {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(id string, client *ClobClient) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(id, client); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("account %s: %w", id, err))
+				mu.Unlock()
+			}
+		}(id, client)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// CancelAll cancels the open orders of every account
+func (m *AccountManager) CancelAll() error {
+	return m.ForEach(func(id string, client *ClobClient) error {
+		_, err := client.CancelAll()
+		return err
+	})
+}
+
+// GetOpenOrders returns the open orders of every account, ordered by account.
+// Orders of the accounts that succeeded are returned along with the error.
+func (m *AccountManager) GetOpenOrders(params *types.OpenOrderParams) ([]AccountOrder, error) {
+	var (
+		mu     sync.Mutex
+		orders []AccountOrder
+	)
+	err := m.ForEach(func(id string, client *ClobClient) error {
+		open, err := client.GetOpenOrders(params, false, "")
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		for _, order := range open {
+			orders = append(orders, AccountOrder{AccountID: id, OpenOrder: order})
+		}
+		mu.Unlock()
+		return nil
+	})
+
+	sort.SliceStable(orders, func(i, j int) bool {
+		return orders[i].AccountID < orders[j].AccountID
+	})
+
+	return orders, err
+}
+
+// Close stops the shared clock sync
+func (m *AccountManager) Close() {
+	if m.clock != nil {
+		m.clock.Stop()
+	}
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// newTestAccountManager returns a manager of accounts with configured
+// credentials, so that adding them makes no requests
+func newTestAccountManager(t *testing.T, maxConcurrency int, ids ...string) *AccountManager {
+	t.Helper()
+
+	m, err := NewAccountManager(&AccountManagerOptions{
+		Host:           "http://127.0.0.1:1",
+		ChainID:        types.ChainPolygon,
+		MaxConcurrency: maxConcurrency,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(m.Close)
+
+	for _, id := range ids {
+		_, err := m.AddAccount(&AccountConfig{
+			ID:         id,
+			PrivateKey: testPrivateKey,
+			APIKey:     &types.ApiKeyCreds{Key: "key-" + id, Secret: "c2VjcmV0", Passphrase: "passphrase"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return m
+}
+
+func TestForEachConcurrency(t *testing.T) {
+	tests := []struct {
+		name           string
+		maxConcurrency int
+		accounts       int
+		want           int
+	}{
+		{"bounded", 2, 6, 2},
+		{"single", 1, 4, 1},
+		{"default", 0, 12, defaultMaxConcurrency},
+		{"fewer accounts than the bound", 8, 3, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids := make([]string, tt.accounts)
+			for i := range ids {
+				ids[i] = fmt.Sprintf("account-%02d", i)
+			}
+			m := newTestAccountManager(t, tt.maxConcurrency, ids...)
+
+			var (
+				mu      sync.Mutex
+				active  int
+				peak    int
+				visited = make(map[string]bool)
+			)
+			err := m.ForEach(func(id string, client *ClobClient) error {
+				mu.Lock()
+				active++
+				peak = max(peak, active)
+				visited[id] = true
+				mu.Unlock()
+
+				time.Sleep(20 * time.Millisecond)
+
+				mu.Lock()
+				active--
+				mu.Unlock()
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(visited) != tt.accounts {
+				t.Errorf("visited %d accounts, want %d", len(visited), tt.accounts)
+			}
+			if peak != tt.want {
+				t.Errorf("peak concurrency = %d, want %d", peak, tt.want)
+			}
+		})
+	}
+}
+
+func TestForEachJoinsErrors(t *testing.T) {
+	m := newTestAccountManager(t, 0, "alice", "bob", "carol")
+
+	errAlice := errors.New("insufficient balance")
+	errCarol := errors.New("rate limited")
+	failures := map[string]error{"alice": errAlice, "carol": errCarol}
+
+	var (
+		mu    sync.Mutex
+		calls int
+	)
+	err := m.ForEach(func(id string, client *ClobClient) error {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+
+		if client != m.accounts[id] {
+			t.Errorf("account %s got another account's client", id)
+		}
+		return failures[id]
+	})
+
+	// Every account runs even when others fail
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+	if !errors.Is(err, errAlice) || !errors.Is(err, errCarol) {
+		t.Fatalf("ForEach error = %v, want both failures", err)
+	}
+	for _, want := range []string{"account alice: insufficient balance", "account carol: rate limited"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("ForEach error %q does not contain %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "bob") {
+		t.Errorf("ForEach error %q mentions the account that succeeded", err)
+	}
+
+	if err := m.ForEach(func(id string, client *ClobClient) error { return nil }); err != nil {
+		t.Errorf("ForEach error = %v, want nil", err)
+	}
+}
@@ -7,8 +7,11 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/lixvyang/polymarket-sdk-go/auth"
 	"github.com/lixvyang/polymarket-sdk-go/types"
 )
@@ -17,12 +20,25 @@ import (
 type ClobClient struct {
 	host          string
 	chainID       types.Chain
-	wallet        *auth.Wallet
+	signer        auth.Signer
 	creds         *types.ApiKeyCreds
+	credsMu       sync.RWMutex
+	refreshMu     sync.Mutex
+	credsStore    CredentialsStore
 	builderConfig *auth.BuilderConfig
 	geoBlockToken string
-	useServerTime bool
+	clock         *ClockSync
+	ownsClock     bool
 	httpClient    *http.Client
+
+	// Order signing
+	signatureType types.SignatureType
+	funder        common.Address
+	contracts     *types.ContractConfig
+	nonceSource   NonceSource
+
+	// Market info cache, possibly shared with other clients
+	market *MarketCache
 }
 
 // ClientConfig represents configuration for the Clob client
@@ -35,10 +51,45 @@ type ClientConfig struct {
 	GeoBlockToken string
 	UseServerTime bool
 	Timeout       time.Duration
+
+	// SignatureType of the orders signed by the client (default SignatureTypeEOA)
+	SignatureType types.SignatureType
+	// FunderAddress is the proxy wallet or Gnosis Safe holding the funds and
+	// the order maker. Defaults to the address derived from the private key
+	// for the signature type.
+	FunderAddress string
+
+	// ClockSyncInterval is how often the server time is measured when
+	// UseServerTime is set (default 5m)
+	ClockSyncInterval time.Duration
+
+	// Signer is used instead of PrivateKey when the key is held elsewhere,
+	// e.g. in a KMS or behind an auth.RemoteSigner
+	Signer auth.Signer
+
+	// CredentialsStore persists API credentials for InitApiCreds, RotateApiKey
+	// and the automatic recovery from revoked keys
+	CredentialsStore CredentialsStore
+
+	// The following may be shared between clients, see AccountManager.
+	// HTTPClient is used instead of a client with Timeout when set.
+	HTTPClient *http.Client
+	// ClockSync is used instead of a per-client one when UseServerTime is set
+	ClockSync *ClockSync
+	// MarketCache holds tick sizes, neg risk flags and fee rates
+	MarketCache *MarketCache
+
+	// Contracts overrides the contract addresses registered for ChainID,
+	// e.g. on a local devnet
+	Contracts *types.ContractConfig
+
+	// NonceSource stamps orders without a nonce with the on-chain exchange
+	// nonce of the maker, e.g. a ctf.Client. Orders default to nonce 0.
+	NonceSource NonceSource
 }
 
 // NewClobClient creates a new CLOB client
-// PrivateKey is optional - if not provided, the client can only access public endpoints
+// PrivateKey (or Signer) is optional - if not provided, the client can only access public endpoints
 func NewClobClient(config *ClientConfig) (*ClobClient, error) {
 	// Normalize host URL
 	host := config.Host
@@ -47,13 +98,37 @@ func NewClobClient(config *ClientConfig) (*ClobClient, error) {
 	}
 
 	// Create wallet from private key (optional for public endpoints)
-	var wallet *auth.Wallet
-	if config.PrivateKey != "" {
-		var err error
-		wallet, err = auth.NewWalletFromHex(config.PrivateKey)
+	signer := config.Signer
+	if signer == nil && config.PrivateKey != "" {
+		wallet, err := auth.NewWalletFromHex(config.PrivateKey)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create wallet from private key: %w", err)
 		}
+		signer = wallet
+	}
+
+	if !config.SignatureType.IsValid() {
+		return nil, fmt.Errorf("invalid signature type %d", config.SignatureType)
+	}
+
+	// The maker is the EOA itself unless funds are held by a proxy wallet or
+	// Safe, whose address is derived from the EOA when not configured
+	var funder common.Address
+	if config.FunderAddress != "" {
+		if !common.IsHexAddress(config.FunderAddress) {
+			return nil, fmt.Errorf("invalid funder address %q", config.FunderAddress)
+		}
+		funder = common.HexToAddress(config.FunderAddress)
+	} else if signer != nil {
+		var err error
+		if config.Contracts != nil {
+			funder, err = auth.DeriveFunderAddress(signer.GetAddress(), config.Contracts, config.SignatureType)
+		} else {
+			funder, err = auth.GetFunderAddress(signer.GetAddress(), config.ChainID, config.SignatureType)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive funder address: %w", err)
+		}
 	}
 
 	// Set default timeout
@@ -62,22 +137,85 @@ func NewClobClient(config *ClientConfig) (*ClobClient, error) {
 		timeout = 30 * time.Second
 	}
 
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: timeout,
+		}
+	}
+
+	market := config.MarketCache
+	if market == nil {
+		market = NewMarketCache()
+	}
+
 	client := &ClobClient{
 		host:          host,
 		chainID:       config.ChainID,
-		wallet:        wallet,
+		signer:        signer,
 		creds:         config.APIKey,
+		credsStore:    config.CredentialsStore,
 		builderConfig: config.BuilderConfig,
 		geoBlockToken: config.GeoBlockToken,
-		useServerTime: config.UseServerTime,
-		httpClient: &http.Client{
-			Timeout: timeout,
-		},
+		httpClient:    httpClient,
+		signatureType: config.SignatureType,
+		funder:        funder,
+		contracts:     config.Contracts,
+		nonceSource:   config.NonceSource,
+		market:        market,
+	}
+
+	// Timestamps are corrected by a background clock sync instead of
+	// fetching the server time before every request
+	if config.UseServerTime {
+		client.clock = config.ClockSync
+		if client.clock == nil {
+			client.clock = NewClockSync(client.GetServerTime, &ClockSyncOptions{
+				Interval: config.ClockSyncInterval,
+			})
+			client.ownsClock = true
+		}
 	}
 
 	return client, nil
 }
 
+// Close stops background work of the client, such as clock synchronization.
+// A shared ClockSync is left running.
+func (c *ClobClient) Close() {
+	if c.clock != nil && c.ownsClock {
+		c.clock.Stop()
+	}
+}
+
+// GetClockSkew returns the measured offset from the server clock. It is zero
+// unless UseServerTime is set.
+func (c *ClobClient) GetClockSkew() ClockSkew {
+	if c.clock == nil {
+		return ClockSkew{}
+	}
+	return c.clock.Skew()
+}
+
+// timestamp returns the corrected timestamp for auth headers, or nil to use
+// the local clock. The first call measures the offset and starts the
+// background clock sync.
+func (c *ClobClient) timestamp() (*int64, error) {
+	if c.clock == nil {
+		return nil, nil
+	}
+
+	if !c.clock.Synced() {
+		if err := c.clock.Sync(); err != nil {
+			return nil, err
+		}
+		c.clock.Start()
+	}
+
+	ts := c.clock.Now().Unix()
+	return &ts, nil
+}
+
 // GetOK makes a GET request to check if the API is OK
 func (c *ClobClient) GetOK() (interface{}, error) {
 	return c.get("/")
@@ -141,12 +279,13 @@ func (c *ClobClient) GetTickSize(tokenID string) (types.TickSize, error) {
 	params := url.Values{}
 	params.Add("token_id", tokenID)
 
+	// The tick size is returned as a JSON number
 	var result struct {
-		MinimumTickSize types.TickSize `json:"minimum_tick_size"`
+		MinimumTickSize json.Number `json:"minimum_tick_size"`
 	}
 
 	err := c.getJSONWithParams(GetTickSize, params, &result)
-	return result.MinimumTickSize, err
+	return types.TickSize(result.MinimumTickSize.String()), err
 }
 
 // GetNegRisk gets negative risk flag for a token
@@ -242,20 +381,16 @@ func (c *ClobClient) GetPricesHistory(params types.PriceHistoryFilterParams) (in
 
 // CreateApiKey creates a new API key
 func (c *ClobClient) CreateApiKey(nonce *uint64) (*types.ApiKeyCreds, error) {
-	if c.wallet == nil {
-		return nil, fmt.Errorf("wallet is required to create API key")
+	if c.signer == nil {
+		return nil, fmt.Errorf("signer is required to create API key")
 	}
 
-	var timestamp *int64
-	if c.useServerTime {
-		serverTime, err := c.GetServerTime()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get server time: %w", err)
-		}
-		timestamp = &serverTime
+	timestamp, err := c.timestamp()
+	if err != nil {
+		return nil, err
 	}
 
-	headers, err := auth.CreateL1Headers(c.wallet.GetPrivateKey(), c.chainID, nonce, timestamp)
+	headers, err := auth.CreateL1Headers(c.signer, c.chainID, nonce, timestamp)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create L1 headers: %w", err)
 	}
@@ -277,23 +412,19 @@ func (c *ClobClient) CreateApiKey(nonce *uint64) (*types.ApiKeyCreds, error) {
 
 // DeriveApiKey derives an existing API key
 func (c *ClobClient) DeriveApiKey(nonce *uint64) (*types.ApiKeyCreds, error) {
-	if c.wallet == nil {
-		return nil, fmt.Errorf("wallet is required to derive API key")
+	if c.signer == nil {
+		return nil, fmt.Errorf("signer is required to derive API key")
 	}
 
 	// Note: Unlike the Go implementation, the TypeScript version only requires L1 auth (signer)
 	// for deriving API keys, not existing credentials. This matches the TypeScript behavior.
 
-	var timestamp *int64
-	if c.useServerTime {
-		serverTime, err := c.GetServerTime()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get server time: %w", err)
-		}
-		timestamp = &serverTime
+	timestamp, err := c.timestamp()
+	if err != nil {
+		return nil, err
 	}
 
-	headers, err := auth.CreateL1Headers(c.wallet.GetPrivateKey(), c.chainID, nonce, timestamp)
+	headers, err := auth.CreateL1Headers(c.signer, c.chainID, nonce, timestamp)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create L1 headers: %w", err)
 	}
@@ -315,7 +446,7 @@ func (c *ClobClient) DeriveApiKey(nonce *uint64) (*types.ApiKeyCreds, error) {
 
 // GetApiKeys gets API keys
 func (c *ClobClient) GetApiKeys() (*types.ApiKeysResponse, error) {
-	if c.creds == nil {
+	if c.GetApiCreds() == nil {
 		return nil, fmt.Errorf("API credentials are required")
 	}
 
@@ -324,19 +455,20 @@ func (c *ClobClient) GetApiKeys() (*types.ApiKeysResponse, error) {
 		RequestPath: GetApiKeys,
 	}
 
-	headers, err := c.createL2Headers(headerArgs)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create L2 headers: %w", err)
-	}
-
 	var result types.ApiKeysResponse
-	err = c.getJSONWithHeaders(GetApiKeys, headers, &result)
+	err := c.withAuthRetry(func() error {
+		headers, err := c.createL2Headers(headerArgs)
+		if err != nil {
+			return fmt.Errorf("failed to create L2 headers: %w", err)
+		}
+		return c.getJSONWithHeaders(GetApiKeys, headers, &result)
+	})
 	return &result, err
 }
 
 // GetClosedOnlyMode gets closed only mode status
 func (c *ClobClient) GetClosedOnlyMode() (*types.BanStatus, error) {
-	if c.creds == nil {
+	if c.GetApiCreds() == nil {
 		return nil, fmt.Errorf("API credentials are required")
 	}
 
@@ -345,19 +477,20 @@ func (c *ClobClient) GetClosedOnlyMode() (*types.BanStatus, error) {
 		RequestPath: ClosedOnly,
 	}
 
-	headers, err := c.createL2Headers(headerArgs)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create L2 headers: %w", err)
-	}
-
 	var result types.BanStatus
-	err = c.getJSONWithHeaders(ClosedOnly, headers, &result)
+	err := c.withAuthRetry(func() error {
+		headers, err := c.createL2Headers(headerArgs)
+		if err != nil {
+			return fmt.Errorf("failed to create L2 headers: %w", err)
+		}
+		return c.getJSONWithHeaders(ClosedOnly, headers, &result)
+	})
 	return &result, err
 }
 
 // DeleteApiKey deletes API key
 func (c *ClobClient) DeleteApiKey() (interface{}, error) {
-	if c.creds == nil {
+	if c.GetApiCreds() == nil {
 		return nil, fmt.Errorf("API credentials are required")
 	}
 
@@ -376,7 +509,7 @@ func (c *ClobClient) DeleteApiKey() (interface{}, error) {
 
 // GetOrder gets an order by ID
 func (c *ClobClient) GetOrder(orderID string) (*types.OpenOrder, error) {
-	if c.creds == nil {
+	if c.GetApiCreds() == nil {
 		return nil, fmt.Errorf("API credentials are required")
 	}
 
@@ -386,19 +519,20 @@ func (c *ClobClient) GetOrder(orderID string) (*types.OpenOrder, error) {
 		RequestPath: endpoint,
 	}
 
-	headers, err := c.createL2Headers(headerArgs)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create L2 headers: %w", err)
-	}
-
 	var result types.OpenOrder
-	err = c.getJSONWithHeaders(endpoint, headers, &result)
+	err := c.withAuthRetry(func() error {
+		headers, err := c.createL2Headers(headerArgs)
+		if err != nil {
+			return fmt.Errorf("failed to create L2 headers: %w", err)
+		}
+		return c.getJSONWithHeaders(endpoint, headers, &result)
+	})
 	return &result, err
 }
 
 // GetTrades gets trades
 func (c *ClobClient) GetTrades(params *types.TradeParams, onlyFirstPage bool, nextCursor string) ([]types.Trade, error) {
-	if c.creds == nil {
+	if c.GetApiCreds() == nil {
 		return nil, fmt.Errorf("API credentials are required")
 	}
 
@@ -407,11 +541,6 @@ func (c *ClobClient) GetTrades(params *types.TradeParams, onlyFirstPage bool, ne
 		RequestPath: GetTrades,
 	}
 
-	headers, err := c.createL2Headers(headerArgs)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create L2 headers: %w", err)
-	}
-
 	queryParams := url.Values{}
 	if nextCursor == "" {
 		nextCursor = types.INITIAL_CURSOR
@@ -444,7 +573,13 @@ func (c *ClobClient) GetTrades(params *types.TradeParams, onlyFirstPage bool, ne
 		NextCursor string        `json:"next_cursor"`
 	}
 
-	err = c.getJSONWithHeadersAndParams(GetTrades, headers, queryParams, &result)
+	err := c.withAuthRetry(func() error {
+		headers, err := c.createL2Headers(headerArgs)
+		if err != nil {
+			return fmt.Errorf("failed to create L2 headers: %w", err)
+		}
+		return c.getJSONWithHeadersAndParams(GetTrades, headers, queryParams, &result)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -462,6 +597,130 @@ func (c *ClobClient) GetTrades(params *types.TradeParams, onlyFirstPage bool, ne
 	return append(result.Data, moreTrades...), nil
 }
 
+// GetOpenOrders gets the open orders of the API key
+func (c *ClobClient) GetOpenOrders(params *types.OpenOrderParams, onlyFirstPage bool, nextCursor string) ([]types.OpenOrder, error) {
+	if c.GetApiCreds() == nil {
+		return nil, fmt.Errorf("API credentials are required")
+	}
+
+	headerArgs := &types.L2HeaderArgs{
+		Method:      "GET",
+		RequestPath: GetOpenOrders,
+	}
+
+	queryParams := url.Values{}
+	if nextCursor == "" {
+		nextCursor = types.INITIAL_CURSOR
+	}
+	queryParams.Add("next_cursor", nextCursor)
+
+	if params != nil {
+		if params.ID != nil {
+			queryParams.Add("id", *params.ID)
+		}
+		if params.Market != nil {
+			queryParams.Add("market", *params.Market)
+		}
+		if params.AssetID != nil {
+			queryParams.Add("asset_id", *params.AssetID)
+		}
+	}
+
+	var result struct {
+		Data       []types.OpenOrder `json:"data"`
+		NextCursor string            `json:"next_cursor"`
+	}
+
+	err := c.withAuthRetry(func() error {
+		headers, err := c.createL2Headers(headerArgs)
+		if err != nil {
+			return fmt.Errorf("failed to create L2 headers: %w", err)
+		}
+		return c.getJSONWithHeadersAndParams(GetOpenOrders, headers, queryParams, &result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if onlyFirstPage || result.NextCursor == types.END_CURSOR || result.NextCursor == "" {
+		return result.Data, nil
+	}
+
+	moreOrders, err := c.GetOpenOrders(params, onlyFirstPage, result.NextCursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(result.Data, moreOrders...), nil
+}
+
+// CancelAll cancels all open orders of the API key
+func (c *ClobClient) CancelAll() (interface{}, error) {
+	if c.GetApiCreds() == nil {
+		return nil, fmt.Errorf("API credentials are required")
+	}
+
+	headerArgs := &types.L2HeaderArgs{
+		Method:      "DELETE",
+		RequestPath: CancelAll,
+	}
+
+	var result interface{}
+	err := c.withAuthRetry(func() error {
+		headers, err := c.createL2Headers(headerArgs)
+		if err != nil {
+			return fmt.Errorf("failed to create L2 headers: %w", err)
+		}
+		result, err = c.deleteWithHeaders(CancelAll, headers)
+		return err
+	})
+	return result, err
+}
+
+// GetBalanceAllowance gets the balance and allowances of the funder as
+// indexed by the CLOB
+func (c *ClobClient) GetBalanceAllowance(params *types.BalanceAllowanceParams) (*types.BalanceAllowanceResponse, error) {
+	var result types.BalanceAllowanceResponse
+	err := c.balanceAllowanceRequest(GetBalanceAllowance, params, &result)
+	return &result, err
+}
+
+// UpdateBalanceAllowance asks the CLOB to refresh its view of the balance
+// and allowances, e.g. after approvals were sent
+func (c *ClobClient) UpdateBalanceAllowance(params *types.BalanceAllowanceParams) error {
+	var result interface{}
+	return c.balanceAllowanceRequest(UpdateBalanceAllowance, params, &result)
+}
+
+func (c *ClobClient) balanceAllowanceRequest(endpoint string, params *types.BalanceAllowanceParams, result interface{}) error {
+	if c.GetApiCreds() == nil {
+		return fmt.Errorf("API credentials are required")
+	}
+	if params == nil {
+		return fmt.Errorf("balance allowance params are required")
+	}
+
+	queryParams := url.Values{}
+	queryParams.Add("asset_type", string(params.AssetType))
+	if params.TokenID != nil {
+		queryParams.Add("token_id", *params.TokenID)
+	}
+	queryParams.Add("signature_type", strconv.Itoa(int(c.signatureType)))
+
+	headerArgs := &types.L2HeaderArgs{
+		Method:      "GET",
+		RequestPath: endpoint,
+	}
+
+	return c.withAuthRetry(func() error {
+		headers, err := c.createL2Headers(headerArgs)
+		if err != nil {
+			return fmt.Errorf("failed to create L2 headers: %w", err)
+		}
+		return c.getJSONWithHeadersAndParams(endpoint, headers, queryParams, result)
+	})
+}
+
 // Helper methods for HTTP requests
 
 func (c *ClobClient) get(endpoint string) (interface{}, error) {
@@ -494,7 +753,7 @@ func (c *ClobClient) getWithParams(endpoint string, params url.Values) (interfac
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return nil, &HTTPError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	var result interface{}
@@ -557,7 +816,7 @@ func (c *ClobClient) getJSONWithHeadersAndParams(endpoint string, headers interf
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return &HTTPError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	return json.NewDecoder(resp.Body).Decode(result)
@@ -604,7 +863,7 @@ func (c *ClobClient) postJSONWithHeaders(endpoint string, headers interface{}, d
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return &HTTPError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	if result != nil {
@@ -638,7 +897,7 @@ func (c *ClobClient) deleteWithHeaders(endpoint string, headers interface{}) (in
 
 	if resp.StatusCode >= 400 {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+		return nil, &HTTPError{StatusCode: resp.StatusCode, Body: string(body)}
 	}
 
 	var result interface{}
@@ -651,20 +910,16 @@ func (c *ClobClient) deleteWithHeaders(endpoint string, headers interface{}) (in
 }
 
 func (c *ClobClient) createL2Headers(args *types.L2HeaderArgs) (interface{}, error) {
-	if c.wallet == nil {
-		return nil, fmt.Errorf("wallet is required for authenticated requests")
+	if c.signer == nil {
+		return nil, fmt.Errorf("signer is required for authenticated requests")
 	}
 
-	var timestamp *int64
-	if c.useServerTime {
-		serverTime, err := c.GetServerTime()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get server time: %w", err)
-		}
-		timestamp = &serverTime
+	timestamp, err := c.timestamp()
+	if err != nil {
+		return nil, err
 	}
 
-	return auth.CreateL2Headers(c.wallet.GetPrivateKey(), c.creds, args, timestamp)
+	return auth.CreateL2Headers(c.signer, c.GetApiCreds(), args, timestamp)
 }
 
 func (c *ClobClient) addHeadersToRequest(req *http.Request, headers interface{}) {
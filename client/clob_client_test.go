@@ -0,0 +1,200 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/lixvyang/polymarket-sdk-go/auth"
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// testPrivateKey is the first Hardhat/Anvil account
+const testPrivateKey = "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+// clobServer is a stand-in for the CLOB API. It issues API keys on the L1
+// auth endpoints, accepts only the latest key on L2 endpoints and verifies
+// both kinds of headers with auth.HeaderVerifier.
+type clobServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	key      *auth.APICredential
+	keyID    string
+	keys     int
+	requests map[string]int
+	routes   map[string]http.HandlerFunc
+
+	// createStatus and deriveStatus make the L1 endpoints fail when set
+	createStatus int
+	deriveStatus int
+	// createEmpty makes key creation return no key, as for an existing nonce
+	createEmpty bool
+	// rejectAll answers every L2 request with 401
+	rejectAll bool
+}
+
+func newCLOBServer(t *testing.T) *clobServer {
+	t.Helper()
+
+	s := &clobServer{
+		requests: make(map[string]int),
+		routes:   make(map[string]http.HandlerFunc),
+	}
+	l1, err := auth.NewHeaderVerifier(&auth.VerifierOptions{ChainID: types.ChainPolygon, Level: auth.AuthL1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l2, err := auth.NewHeaderVerifier(&auth.VerifierOptions{ChainID: types.ChainPolygon, Level: auth.AuthL2, Credentials: s})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("POST /auth/api-key", l1.Middleware(http.HandlerFunc(s.createKey)))
+	mux.Handle("GET /auth/derive-api-key", l1.Middleware(http.HandlerFunc(s.deriveKey)))
+	mux.Handle("GET /auth/api-keys", l2.Middleware(http.HandlerFunc(s.listKeys)))
+	mux.HandleFunc("/", s.route)
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests[r.Method+" "+r.URL.Path]++
+		rejectAll := s.rejectAll && r.Header.Get("POLY_API_KEY") != ""
+		s.mu.Unlock()
+
+		if rejectAll {
+			http.Error(w, `{"error":"Unauthorized/Invalid api key"}`, http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// handle registers a handler for "METHOD /path" requests
+func (s *clobServer) handle(pattern string, handler http.HandlerFunc) {
+	s.mu.Lock()
+	s.routes[pattern] = handler
+	s.mu.Unlock()
+}
+
+// count returns the number of "METHOD /path" requests received
+func (s *clobServer) count(pattern string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[pattern]
+}
+
+// issued returns the number of API keys issued
+func (s *clobServer) issued() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.keys
+}
+
+// LookupAPIKey accepts only the latest key
+func (s *clobServer) LookupAPIKey(ctx context.Context, apiKey string) (*auth.APICredential, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.key == nil || apiKey != s.keyID {
+		return nil, nil
+	}
+	key := *s.key
+	return &key, nil
+}
+
+func (s *clobServer) route(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	handler := s.routes[r.Method+" "+r.URL.Path]
+	s.mu.Unlock()
+
+	if handler == nil {
+		http.NotFound(w, r)
+		return
+	}
+	handler(w, r)
+}
+
+func (s *clobServer) createKey(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.createStatus != 0 {
+		http.Error(w, `{"error":"Could not create api key"}`, s.createStatus)
+		return
+	}
+	if s.createEmpty {
+		writeJSON(w, types.ApiKeyRaw{})
+		return
+	}
+
+	address, _ := auth.AddressFromContext(r.Context())
+	s.issue(address.Hex())
+	writeJSON(w, s.raw())
+}
+
+func (s *clobServer) deriveKey(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.deriveStatus != 0 {
+		http.Error(w, `{"error":"Could not derive api key"}`, s.deriveStatus)
+		return
+	}
+	if s.key == nil {
+		address, _ := auth.AddressFromContext(r.Context())
+		s.issue(address.Hex())
+	}
+	writeJSON(w, s.raw())
+}
+
+func (s *clobServer) listKeys(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeJSON(w, types.ApiKeysResponse{APIKeys: []string{s.keyID}})
+}
+
+// issue replaces the API key. Callers must hold s.mu.
+func (s *clobServer) issue(address string) {
+	s.keys++
+	s.keyID = fmt.Sprintf("key-%d", s.keys)
+	s.key = &auth.APICredential{
+		Address:    address,
+		Secret:     fmt.Sprintf("secret-%d", s.keys),
+		Passphrase: fmt.Sprintf("passphrase-%d", s.keys),
+	}
+}
+
+// raw returns the API key response. Callers must hold s.mu.
+func (s *clobServer) raw() types.ApiKeyRaw {
+	return types.ApiKeyRaw{APIKey: s.keyID, Secret: s.key.Secret, Passphrase: s.key.Passphrase}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// newTestClobClient returns a client of server signing with testPrivateKey
+func newTestClobClient(t *testing.T, server *clobServer, config ClientConfig) *ClobClient {
+	t.Helper()
+
+	config.Host = server.URL
+	config.ChainID = types.ChainPolygon
+	if config.Signer == nil && config.PrivateKey == "" {
+		config.PrivateKey = testPrivateKey
+	}
+
+	client, err := NewClobClient(&config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ClockSyncOptions configures a ClockSync
+type ClockSyncOptions struct {
+	// Interval between synchronizations (default 5m)
+	Interval time.Duration
+
+	// OnSync is called after every successful synchronization, for monitoring
+	OnSync func(skew ClockSkew)
+}
+
+// ClockSkew describes the measured difference between local and server time
+type ClockSkew struct {
+	// Offset is added to the local clock to get the server time
+	Offset time.Duration
+	// RTT is the round-trip time of the last measurement
+	RTT      time.Duration
+	LastSync time.Time
+	Syncs    uint64
+	Errors   uint64
+	// LastError is the error of the last failed synchronization
+	LastError error
+}
+
+// ClockSync tracks the offset between the local clock and the server clock
+// in the background, so timestamps can be corrected without a round trip
+type ClockSync struct {
+	fetch   func() (int64, error)
+	options ClockSyncOptions
+
+	mu      sync.RWMutex
+	skew    ClockSkew
+	synced  bool
+	running bool
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewClockSync creates a clock sync that reads the server time, in Unix
+// seconds, with fetch
+func NewClockSync(fetch func() (int64, error), options *ClockSyncOptions) *ClockSync {
+	opts := ClockSyncOptions{}
+	if options != nil {
+		opts = *options
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = 5 * time.Minute
+	}
+
+	return &ClockSync{
+		fetch:   fetch,
+		options: opts,
+	}
+}
+
+// Sync measures the clock offset once
+func (cs *ClockSync) Sync() error {
+	sentAt := time.Now()
+	serverTime, err := cs.fetch()
+	receivedAt := time.Now()
+
+	if err != nil {
+		cs.mu.Lock()
+		cs.skew.Errors++
+		cs.skew.LastError = err
+		cs.mu.Unlock()
+		return fmt.Errorf("failed to get server time: %w", err)
+	}
+
+	// The server time is truncated to seconds, so its midpoint is compared
+	// with the local time halfway through the round trip
+	rtt := receivedAt.Sub(sentAt)
+	server := time.Unix(serverTime, 0).Add(500 * time.Millisecond)
+	offset := server.Sub(sentAt.Add(rtt / 2))
+
+	cs.mu.Lock()
+	cs.skew.Offset = offset
+	cs.skew.RTT = rtt
+	cs.skew.LastSync = receivedAt
+	cs.skew.Syncs++
+	cs.skew.LastError = nil
+	cs.synced = true
+	skew := cs.skew
+	cs.mu.Unlock()
+
+	if cs.options.OnSync != nil {
+		cs.options.OnSync(skew)
+	}
+
+	return nil
+}
+
+// Start synchronizes periodically in the background until Stop is called.
+// It does nothing if already running.
+func (cs *ClockSync) Start() {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	if cs.running {
+		return
+	}
+
+	cs.running = true
+	cs.stop = make(chan struct{})
+	cs.done = make(chan struct{})
+
+	go cs.loop(cs.stop, cs.done)
+}
+
+// Stop stops the background synchronization
+func (cs *ClockSync) Stop() {
+	cs.mu.Lock()
+	if !cs.running {
+		cs.mu.Unlock()
+		return
+	}
+	cs.running = false
+	close(cs.stop)
+	done := cs.done
+	cs.mu.Unlock()
+
+	<-done
+}
+
+func (cs *ClockSync) loop(stop, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(cs.options.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			// Failures keep the previous offset and are reported in Skew
+			cs.Sync()
+		}
+	}
+}
+
+// Synced reports whether the offset has been measured at least once
+func (cs *ClockSync) Synced() bool {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.synced
+}
+
+// Now returns the current server time estimate
+func (cs *ClockSync) Now() time.Time {
+	cs.mu.RLock()
+	offset := cs.skew.Offset
+	cs.mu.RUnlock()
+
+	return time.Now().Add(offset)
+}
+
+// Skew returns the latest clock skew measurement
+func (cs *ClockSync) Skew() ClockSkew {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.skew
+}
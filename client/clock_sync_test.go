@@ -0,0 +1,121 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// serveSkewedTime serves the time of a server clock running skew ahead of the
+// local one. Each request waits until the server clock is halfway through a
+// second, reads it, and waits as long again before answering, so that the
+// read happens at the local midpoint of the round trip and the truncated
+// second plus half a second is the exact server time of the read.
+func serveSkewedTime(server *clobServer, skew time.Duration) {
+	server.handle("GET /time", func(w http.ResponseWriter, r *http.Request) {
+		now := time.Now().Add(skew)
+		wait := (time.Second + 500*time.Millisecond - time.Duration(now.Nanosecond())) % time.Second
+		time.Sleep(wait)
+		seconds := time.Now().Add(skew).Unix()
+		time.Sleep(wait)
+		writeJSON(w, seconds)
+	})
+}
+
+func TestClockSyncSkew(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		skew time.Duration
+	}{
+		{"server ahead", 42*time.Second + 300*time.Millisecond},
+		{"server behind", -17*time.Second - 800*time.Millisecond},
+		{"in sync", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := newCLOBServer(t)
+			serveSkewedTime(server, tt.skew)
+			client := newTestClobClient(t, server, ClientConfig{})
+
+			var synced []ClockSkew
+			cs := NewClockSync(client.GetServerTime, &ClockSyncOptions{
+				OnSync: func(skew ClockSkew) { synced = append(synced, skew) },
+			})
+			if cs.Synced() {
+				t.Fatal("Synced before the first measurement")
+			}
+
+			before := time.Now()
+			if err := cs.Sync(); err != nil {
+				t.Fatal(err)
+			}
+			after := time.Now()
+
+			skew := cs.Skew()
+			const tolerance = 25 * time.Millisecond
+			if diff := skew.Offset - tt.skew; diff < -tolerance || diff > tolerance {
+				t.Errorf("offset = %v, want %v ± %v", skew.Offset, tt.skew, tolerance)
+			}
+			if skew.RTT <= 0 || skew.RTT > after.Sub(before) {
+				t.Errorf("RTT = %v, want within (0, %v]", skew.RTT, after.Sub(before))
+			}
+			if skew.LastSync.Before(before) || skew.LastSync.After(after) {
+				t.Errorf("last sync = %v, want between %v and %v", skew.LastSync, before, after)
+			}
+			if skew.Syncs != 1 || skew.Errors != 0 || skew.LastError != nil {
+				t.Errorf("syncs = %d, errors = %d, last error = %v", skew.Syncs, skew.Errors, skew.LastError)
+			}
+			if !cs.Synced() {
+				t.Error("not Synced after a measurement")
+			}
+			if len(synced) != 1 || synced[0] != skew {
+				t.Errorf("OnSync got %+v, want %+v", synced, skew)
+			}
+
+			if diff := cs.Now().Sub(time.Now().Add(tt.skew)); diff < -tolerance || diff > tolerance {
+				t.Errorf("Now is %v off the server clock", diff)
+			}
+		})
+	}
+}
+
+func TestClockSyncErrorKeepsOffset(t *testing.T) {
+	t.Parallel()
+
+	server := newCLOBServer(t)
+	skew := 5*time.Second + 500*time.Millisecond
+	serveSkewedTime(server, skew)
+	client := newTestClobClient(t, server, ClientConfig{})
+
+	cs := NewClockSync(client.GetServerTime, nil)
+	if err := cs.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	offset := cs.Skew().Offset
+
+	server.handle("GET /time", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":"unavailable"}`, http.StatusServiceUnavailable)
+	})
+	if err := cs.Sync(); err == nil {
+		t.Fatal("Sync succeeded against a failing server")
+	}
+
+	got := cs.Skew()
+	if got.Offset != offset || got.Syncs != 1 || got.Errors != 1 || got.LastError == nil {
+		t.Errorf("skew after failure = %+v, want offset %v, 1 sync and 1 error", got, offset)
+	}
+
+	// A later success clears the error
+	serveSkewedTime(server, skew)
+	if err := cs.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if got := cs.Skew(); got.Syncs != 2 || got.Errors != 1 || got.LastError != nil {
+		t.Errorf("skew after recovery = %+v, want 2 syncs, 1 error and no last error", got)
+	}
+}
@@ -0,0 +1,293 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/lixvyang/polymarket-sdk-go/internal/atomicfile"
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// CredentialsStore persists API credentials per wallet address
+type CredentialsStore interface {
+	// Load returns the stored credentials, or nil if there are none
+	Load(address string) (*types.ApiKeyCreds, error)
+	Save(address string, creds *types.ApiKeyCreds) error
+	Delete(address string) error
+}
+
+// MemoryCredentialsStore keeps API credentials in memory
+type MemoryCredentialsStore struct {
+	mu    sync.RWMutex
+	creds map[string]types.ApiKeyCreds
+}
+
+// NewMemoryCredentialsStore creates an empty in-memory credentials store
+func NewMemoryCredentialsStore() *MemoryCredentialsStore {
+	return &MemoryCredentialsStore{creds: make(map[string]types.ApiKeyCreds)}
+}
+
+// Load returns the stored credentials, or nil if there are none
+func (s *MemoryCredentialsStore) Load(address string) (*types.ApiKeyCreds, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	creds, ok := s.creds[strings.ToLower(address)]
+	if !ok {
+		return nil, nil
+	}
+	return &creds, nil
+}
+
+// Save stores credentials for an address
+func (s *MemoryCredentialsStore) Save(address string, creds *types.ApiKeyCreds) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.creds[strings.ToLower(address)] = *creds
+	return nil
+}
+
+// Delete removes the credentials of an address
+func (s *MemoryCredentialsStore) Delete(address string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.creds, strings.ToLower(address))
+	return nil
+}
+
+// FileCredentialsStore keeps API credentials in a JSON file readable only by
+// the owner, keyed by lowercase wallet address
+type FileCredentialsStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileCredentialsStore creates a credentials store backed by the given file,
+// which is created on first save
+func NewFileCredentialsStore(path string) *FileCredentialsStore {
+	return &FileCredentialsStore{path: path}
+}
+
+// Load returns the stored credentials, or nil if there are none
+func (s *FileCredentialsStore) Load(address string) (*types.ApiKeyCreds, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	creds, ok := all[strings.ToLower(address)]
+	if !ok {
+		return nil, nil
+	}
+	return &creds, nil
+}
+
+// Save stores credentials for an address
+func (s *FileCredentialsStore) Save(address string, creds *types.ApiKeyCreds) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	all[strings.ToLower(address)] = *creds
+	return s.write(all)
+}
+
+// Delete removes the credentials of an address
+func (s *FileCredentialsStore) Delete(address string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	delete(all, strings.ToLower(address))
+	return s.write(all)
+}
+
+func (s *FileCredentialsStore) read() (map[string]types.ApiKeyCreds, error) {
+	all := make(map[string]types.ApiKeyCreds)
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("failed to decode credentials file: %w", err)
+	}
+
+	return all, nil
+}
+
+// write saves all credentials to a file readable only by the owner
+func (s *FileCredentialsStore) write(all map[string]types.ApiKeyCreds) error {
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode credentials: %w", err)
+	}
+
+	if err := atomicfile.WriteFile(s.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+
+	return nil
+}
+
+// GetApiCreds returns the API credentials in use, or nil
+func (c *ClobClient) GetApiCreds() *types.ApiKeyCreds {
+	c.credsMu.RLock()
+	defer c.credsMu.RUnlock()
+	return c.creds
+}
+
+// SetApiCreds replaces the API credentials in use
+func (c *ClobClient) SetApiCreds(creds *types.ApiKeyCreds) {
+	c.credsMu.Lock()
+	c.creds = creds
+	c.credsMu.Unlock()
+}
+
+// CreateOrDeriveApiKey creates an API key, or derives the existing one if
+// the key for the nonce already exists
+func (c *ClobClient) CreateOrDeriveApiKey(nonce *uint64) (*types.ApiKeyCreds, error) {
+	creds, createErr := c.CreateApiKey(nonce)
+	if createErr == nil && creds.Key != "" {
+		return creds, nil
+	}
+
+	creds, err := c.DeriveApiKey(nonce)
+	if err != nil {
+		return nil, errors.Join(createErr, err)
+	}
+
+	return creds, nil
+}
+
+// InitApiCreds makes sure the client has API credentials: the configured
+// ones, else those in the credentials store, else newly created or derived
+// ones, which are then saved to the store
+func (c *ClobClient) InitApiCreds() (*types.ApiKeyCreds, error) {
+	if creds := c.GetApiCreds(); creds != nil {
+		return creds, nil
+	}
+
+	if c.signer == nil {
+		return nil, fmt.Errorf("signer is required to initialize API credentials")
+	}
+
+	address := c.signer.GetAddress().Hex()
+	if c.credsStore != nil {
+		creds, err := c.credsStore.Load(address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load API credentials: %w", err)
+		}
+		if creds != nil {
+			c.SetApiCreds(creds)
+			return creds, nil
+		}
+	}
+
+	creds, err := c.CreateOrDeriveApiKey(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.storeApiCreds(creds); err != nil {
+		return nil, err
+	}
+
+	return creds, nil
+}
+
+// RotateApiKey deletes the current API key and creates a new one
+func (c *ClobClient) RotateApiKey() (*types.ApiKeyCreds, error) {
+	if c.signer == nil {
+		return nil, fmt.Errorf("signer is required to rotate API key")
+	}
+
+	if c.GetApiCreds() != nil {
+		// A key that is already revoked is rejected with 401 and needs no deletion
+		if _, err := c.DeleteApiKey(); err != nil && !IsUnauthorized(err) {
+			return nil, fmt.Errorf("failed to delete API key: %w", err)
+		}
+	}
+
+	creds, err := c.CreateOrDeriveApiKey(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.storeApiCreds(creds); err != nil {
+		return nil, err
+	}
+
+	return creds, nil
+}
+
+// storeApiCreds puts credentials in use and saves them to the store
+func (c *ClobClient) storeApiCreds(creds *types.ApiKeyCreds) error {
+	c.SetApiCreds(creds)
+
+	if c.credsStore == nil {
+		return nil
+	}
+
+	if err := c.credsStore.Save(c.signer.GetAddress().Hex(), creds); err != nil {
+		return fmt.Errorf("failed to save API credentials: %w", err)
+	}
+
+	return nil
+}
+
+// withAuthRetry runs an L2 authenticated request. When it fails with 401,
+// e.g. because the key was revoked, the credentials are created or derived
+// again and the request is retried once.
+func (c *ClobClient) withAuthRetry(request func() error) error {
+	stale := c.GetApiCreds()
+
+	err := request()
+	if !IsUnauthorized(err) || c.signer == nil {
+		return err
+	}
+
+	if refreshErr := c.refreshApiCreds(stale); refreshErr != nil {
+		return errors.Join(err, refreshErr)
+	}
+
+	return request()
+}
+
+// refreshApiCreds replaces stale credentials, unless another request already did
+func (c *ClobClient) refreshApiCreds(stale *types.ApiKeyCreds) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	if c.GetApiCreds() != stale {
+		return nil
+	}
+
+	creds, err := c.CreateOrDeriveApiKey(nil)
+	if err != nil {
+		return fmt.Errorf("failed to refresh API credentials: %w", err)
+	}
+
+	return c.storeApiCreds(creds)
+}
@@ -0,0 +1,280 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// revokedCreds are credentials the CLOB stand-in does not know
+var revokedCreds = &types.ApiKeyCreds{Key: "revoked", Secret: "secret", Passphrase: "passphrase"}
+
+func TestAuthRetryRefreshesOnce(t *testing.T) {
+	server := newCLOBServer(t)
+	store := NewMemoryCredentialsStore()
+	client := newTestClobClient(t, server, ClientConfig{APIKey: revokedCreds, CredentialsStore: store})
+
+	keys, err := client.GetApiKeys()
+	if err != nil {
+		t.Fatalf("GetApiKeys failed: %v", err)
+	}
+	if len(keys.APIKeys) != 1 || keys.APIKeys[0] != "key-1" {
+		t.Errorf("GetApiKeys = %v, want [key-1]", keys.APIKeys)
+	}
+
+	if n := server.count("GET /auth/api-keys"); n != 2 {
+		t.Errorf("%d requests, want the 401 and one retry", n)
+	}
+	if n := server.issued(); n != 1 {
+		t.Errorf("%d keys created, want 1", n)
+	}
+	if creds := client.GetApiCreds(); creds.Key != "key-1" {
+		t.Errorf("client key = %s, want key-1", creds.Key)
+	}
+	saved, err := store.Load(client.signer.GetAddress().Hex())
+	if err != nil || saved == nil || saved.Key != "key-1" {
+		t.Errorf("store = %+v, %v, want key-1", saved, err)
+	}
+
+	// Valid credentials are not refreshed again
+	if _, err := client.GetApiKeys(); err != nil {
+		t.Fatal(err)
+	}
+	if n := server.count("GET /auth/api-keys"); n != 3 {
+		t.Errorf("%d requests, want 3", n)
+	}
+	if n := server.issued(); n != 1 {
+		t.Errorf("%d keys created, want 1", n)
+	}
+}
+
+func TestAuthRetryConcurrent(t *testing.T) {
+	server := newCLOBServer(t)
+	client := newTestClobClient(t, server, ClientConfig{APIKey: revokedCreds})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.GetApiKeys()
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("GetApiKeys failed: %v", err)
+		}
+	}
+	if n := server.issued(); n != 1 {
+		t.Errorf("%d keys created by concurrent 401s, want 1", n)
+	}
+}
+
+func TestAuthRetryRefreshFails(t *testing.T) {
+	server := newCLOBServer(t)
+	server.createStatus = 500
+	server.deriveStatus = 500
+	client := newTestClobClient(t, server, ClientConfig{APIKey: revokedCreds})
+
+	_, err := client.GetApiKeys()
+	if err == nil {
+		t.Fatal("GetApiKeys succeeded with revoked credentials")
+	}
+	if !IsUnauthorized(err) {
+		t.Errorf("error %v does not report the 401", err)
+	}
+	for _, want := range []string{"failed to refresh API credentials", "Could not create api key", "Could not derive api key"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+
+	if n := server.count("GET /auth/api-keys"); n != 1 {
+		t.Errorf("%d requests, want no retry", n)
+	}
+	if client.GetApiCreds() != revokedCreds {
+		t.Error("a failed refresh replaced the credentials")
+	}
+}
+
+func TestAuthRetryOnlyOnce(t *testing.T) {
+	server := newCLOBServer(t)
+	server.rejectAll = true
+	client := newTestClobClient(t, server, ClientConfig{APIKey: revokedCreds})
+
+	if _, err := client.GetApiKeys(); !IsUnauthorized(err) {
+		t.Fatalf("GetApiKeys = %v, want 401", err)
+	}
+	if n := server.count("GET /auth/api-keys"); n != 2 {
+		t.Errorf("%d requests, want the 401 and one retry", n)
+	}
+	if n := server.issued(); n != 1 {
+		t.Errorf("%d keys created, want 1", n)
+	}
+}
+
+func TestCreateOrDeriveApiKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(s *clobServer)
+		key     string
+		derived int
+		err     []string
+	}{
+		{
+			name: "created",
+			key:  "key-1",
+		},
+		{
+			name:    "create fails",
+			setup:   func(s *clobServer) { s.createStatus = 400 },
+			key:     "key-1",
+			derived: 1,
+		},
+		{
+			name:    "nonce already used",
+			setup:   func(s *clobServer) { s.createEmpty = true },
+			key:     "key-1",
+			derived: 1,
+		},
+		{
+			name: "both fail",
+			setup: func(s *clobServer) {
+				s.createStatus = 400
+				s.deriveStatus = 500
+			},
+			derived: 1,
+			err:     []string{"HTTP 400", "Could not create api key", "HTTP 500", "Could not derive api key"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newCLOBServer(t)
+			if tt.setup != nil {
+				tt.setup(server)
+			}
+			client := newTestClobClient(t, server, ClientConfig{})
+
+			creds, err := client.CreateOrDeriveApiKey(nil)
+			if n := server.count("GET /auth/derive-api-key"); n != tt.derived {
+				t.Errorf("%d derive requests, want %d", n, tt.derived)
+			}
+
+			if tt.err != nil {
+				if err == nil {
+					t.Fatal("CreateOrDeriveApiKey succeeded")
+				}
+				for _, want := range tt.err {
+					if !strings.Contains(err.Error(), want) {
+						t.Errorf("error %q does not contain %q", err, want)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateOrDeriveApiKey failed: %v", err)
+			}
+			if creds.Key != tt.key || creds.Secret == "" || creds.Passphrase == "" {
+				t.Errorf("creds = %+v, want key %s", creds, tt.key)
+			}
+		})
+	}
+}
+
+func TestInitApiCreds(t *testing.T) {
+	server := newCLOBServer(t)
+	store := NewMemoryCredentialsStore()
+	client := newTestClobClient(t, server, ClientConfig{CredentialsStore: store})
+	address := client.signer.GetAddress().Hex()
+
+	stored := &types.ApiKeyCreds{Key: "stored", Secret: "secret", Passphrase: "passphrase"}
+	if err := store.Save(strings.ToUpper(address), stored); err != nil {
+		t.Fatal(err)
+	}
+	creds, err := client.InitApiCreds()
+	if err != nil || creds.Key != "stored" {
+		t.Fatalf("InitApiCreds = %+v, %v, want the stored key", creds, err)
+	}
+	if n := server.issued(); n != 0 {
+		t.Errorf("%d keys created, want the stored one used", n)
+	}
+
+	// Without stored credentials a key is created and saved
+	client = newTestClobClient(t, server, ClientConfig{CredentialsStore: NewMemoryCredentialsStore()})
+	creds, err = client.InitApiCreds()
+	if err != nil || creds.Key != "key-1" {
+		t.Fatalf("InitApiCreds = %+v, %v, want key-1", creds, err)
+	}
+	saved, err := client.credsStore.Load(address)
+	if err != nil || saved == nil || *saved != *creds {
+		t.Errorf("store = %+v, %v, want %+v", saved, err, creds)
+	}
+}
+
+func TestFileCredentialsStore(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "credentials.json")
+	store := NewFileCredentialsStore(path)
+
+	alice := types.ApiKeyCreds{Key: "alice-key", Secret: "alice-secret", Passphrase: "alice-passphrase"}
+	bob := types.ApiKeyCreds{Key: "bob-key", Secret: "bob-secret", Passphrase: "bob-passphrase"}
+	const aliceAddress = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+	const bobAddress = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
+
+	if creds, err := store.Load(aliceAddress); creds != nil || err != nil {
+		t.Fatalf("Load from a missing file = %+v, %v", creds, err)
+	}
+	if err := store.Save(aliceAddress, &alice); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(bobAddress, &bob); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("%d files in the directory, want only the credentials", len(entries))
+	}
+
+	// A new store reads the file, with addresses in any case
+	reopened := NewFileCredentialsStore(path)
+	creds, err := reopened.Load(strings.ToLower(aliceAddress))
+	if err != nil || creds == nil || *creds != alice {
+		t.Errorf("Load = %+v, %v, want %+v", creds, err, alice)
+	}
+
+	if err := reopened.Delete(aliceAddress); err != nil {
+		t.Fatal(err)
+	}
+	if creds, err := store.Load(aliceAddress); creds != nil || err != nil {
+		t.Errorf("Load after Delete = %+v, %v", creds, err)
+	}
+	creds, err = store.Load(bobAddress)
+	if err != nil || creds == nil || *creds != bob {
+		t.Errorf("Load = %+v, %v, want %+v", creds, err, bob)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Load(bobAddress); err == nil {
+		t.Error("Load accepted a corrupt file")
+	}
+}
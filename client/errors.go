@@ -0,0 +1,24 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// HTTPError is returned for HTTP responses with an error status code
+type HTTPError struct {
+	StatusCode int
+	Body       string
+}
+
+// Error implements the error interface
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Body)
+}
+
+// IsUnauthorized reports whether err is an HTTP 401 response
+func IsUnauthorized(err error) bool {
+	var httpErr *HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusUnauthorized
+}
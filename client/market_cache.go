@@ -0,0 +1,79 @@
+package client
+
+import (
+	"sync"
+
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// MarketCache caches per-token market info used for order creation. It is
+// safe for concurrent use and can be shared by several clients.
+type MarketCache struct {
+	mu        sync.RWMutex
+	tickSizes types.TickSizes
+	negRisk   types.NegRisk
+	feeRates  types.FeeRates
+}
+
+// NewMarketCache creates an empty market cache
+func NewMarketCache() *MarketCache {
+	return &MarketCache{
+		tickSizes: make(types.TickSizes),
+		negRisk:   make(types.NegRisk),
+		feeRates:  make(types.FeeRates),
+	}
+}
+
+// TickSize returns the cached tick size of a token
+func (m *MarketCache) TickSize(tokenID string) (types.TickSize, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	tickSize, ok := m.tickSizes[tokenID]
+	return tickSize, ok
+}
+
+// SetTickSize caches the tick size of a token
+func (m *MarketCache) SetTickSize(tokenID string, tickSize types.TickSize) {
+	m.mu.Lock()
+	m.tickSizes[tokenID] = tickSize
+	m.mu.Unlock()
+}
+
+// NegRisk returns the cached neg risk flag of a token
+func (m *MarketCache) NegRisk(tokenID string) (bool, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	negRisk, ok := m.negRisk[tokenID]
+	return negRisk, ok
+}
+
+// SetNegRisk caches the neg risk flag of a token
+func (m *MarketCache) SetNegRisk(tokenID string, negRisk bool) {
+	m.mu.Lock()
+	m.negRisk[tokenID] = negRisk
+	m.mu.Unlock()
+}
+
+// FeeRate returns the cached fee rate of a token
+func (m *MarketCache) FeeRate(tokenID string) (int, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	feeRate, ok := m.feeRates[tokenID]
+	return feeRate, ok
+}
+
+// SetFeeRate caches the fee rate of a token
+func (m *MarketCache) SetFeeRate(tokenID string, feeRate int) {
+	m.mu.Lock()
+	m.feeRates[tokenID] = feeRate
+	m.mu.Unlock()
+}
+
+// Clear forgets all cached market info
+func (m *MarketCache) Clear() {
+	m.mu.Lock()
+	m.tickSizes = make(types.TickSizes)
+	m.negRisk = make(types.NegRisk)
+	m.feeRates = make(types.FeeRates)
+	m.mu.Unlock()
+}
@@ -0,0 +1,376 @@
+package client
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lixvyang/polymarket-sdk-go/data"
+	"github.com/lixvyang/polymarket-sdk-go/gamma"
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+var (
+	conditionIDPattern = regexp.MustCompile(`^0x[0-9a-fA-F]{64}$`)
+	tokenIDPattern     = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// MarketOutcome is one outcome of a market with its token and book top
+type MarketOutcome struct {
+	Outcome string
+	TokenID string
+	// Price is the Gamma outcome price
+	Price *types.Decimal
+	// BestBid and BestAsk are nil when that side of the book is empty
+	BestBid *types.Decimal
+	BestAsk *types.Decimal
+}
+
+// UnifiedMarket joins the Gamma, CLOB and Data API views of a market
+type UnifiedMarket struct {
+	ID          string
+	ConditionID string
+	QuestionID  string
+	Slug        string
+	Question    string
+	EndDate     string
+	Active      bool
+	Closed      bool
+
+	Outcomes     []MarketOutcome
+	TickSize     types.TickSize
+	MinOrderSize types.Decimal
+	NegRisk      bool
+	FeeRateBps   int
+	OpenInterest *types.Decimal
+
+	// Gamma is the Gamma market the others are joined to
+	Gamma *gamma.Market
+	// FetchedAt is when the market was resolved
+	FetchedAt time.Time
+}
+
+// Outcome returns the outcome of a token
+func (m *UnifiedMarket) Outcome(tokenID string) (*MarketOutcome, bool) {
+	for i := range m.Outcomes {
+		if m.Outcomes[i].TokenID == tokenID {
+			return &m.Outcomes[i], true
+		}
+	}
+	return nil, false
+}
+
+// MarketResolverOptions configures a MarketResolver
+type MarketResolverOptions struct {
+	// TTL is how long resolved markets are cached (default 1 minute)
+	TTL time.Duration
+}
+
+// MarketResolver resolves a slug, condition ID or token ID to a
+// UnifiedMarket, caching the results. It is safe for concurrent use.
+type MarketResolver struct {
+	clob  *ClobClient
+	gamma *gamma.GammaSDK
+	data  *data.DataSDK
+	ttl   time.Duration
+
+	mu      sync.RWMutex
+	markets map[string]*UnifiedMarket // by condition ID
+	slugs   map[string]string
+	tokens  map[string]string
+}
+
+// NewMarketResolver creates a market resolver. The CLOB client is required;
+// the Gamma and Data SDKs default to ones with the default configuration.
+func NewMarketResolver(clob *ClobClient, gammaSDK *gamma.GammaSDK, dataSDK *data.DataSDK, options *MarketResolverOptions) (*MarketResolver, error) {
+	if clob == nil {
+		return nil, fmt.Errorf("a CLOB client is required")
+	}
+	if gammaSDK == nil {
+		gammaSDK = gamma.NewGammaSDK(nil)
+	}
+	if dataSDK == nil {
+		dataSDK = data.NewDataSDK(nil)
+	}
+
+	ttl := time.Minute
+	if options != nil && options.TTL > 0 {
+		ttl = options.TTL
+	}
+
+	return &MarketResolver{
+		clob:    clob,
+		gamma:   gammaSDK,
+		data:    dataSDK,
+		ttl:     ttl,
+		markets: make(map[string]*UnifiedMarket),
+		slugs:   make(map[string]string),
+		tokens:  make(map[string]string),
+	}, nil
+}
+
+// Resolve resolves a condition ID (0x-prefixed hash), token ID (decimal) or slug
+func (r *MarketResolver) Resolve(id string) (*UnifiedMarket, error) {
+	switch {
+	case conditionIDPattern.MatchString(id):
+		return r.ByConditionID(id)
+	case tokenIDPattern.MatchString(id):
+		return r.ByTokenID(id)
+	default:
+		return r.BySlug(id)
+	}
+}
+
+// BySlug resolves a market by its Gamma slug
+func (r *MarketResolver) BySlug(slug string) (*UnifiedMarket, error) {
+	if market := r.cached(r.slugs, slug); market != nil {
+		return market, nil
+	}
+
+	gammaMarket, err := r.gamma.GetMarketBySlug(slug, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get market %s: %w", slug, err)
+	}
+	if gammaMarket == nil {
+		return nil, fmt.Errorf("market %s not found", slug)
+	}
+
+	return r.resolve(gammaMarket)
+}
+
+// ByConditionID resolves a market by its condition ID
+func (r *MarketResolver) ByConditionID(conditionID string) (*UnifiedMarket, error) {
+	conditionID = strings.ToLower(conditionID)
+	if market := r.cached(nil, conditionID); market != nil {
+		return market, nil
+	}
+
+	return r.fetch(&gamma.UpdatedMarketQuery{ConditionIDs: []string{conditionID}}, conditionID, func(m *gamma.Market) bool {
+		return strings.EqualFold(m.ConditionID, conditionID)
+	})
+}
+
+// ByTokenID resolves a market by the token ID of one of its outcomes
+func (r *MarketResolver) ByTokenID(tokenID string) (*UnifiedMarket, error) {
+	if market := r.cached(r.tokens, tokenID); market != nil {
+		return market, nil
+	}
+
+	return r.fetch(&gamma.UpdatedMarketQuery{ClobTokenIDs: []string{tokenID}}, tokenID, func(m *gamma.Market) bool {
+		return slices.Contains(m.ClobTokenIDs, tokenID)
+	})
+}
+
+// Invalidate drops the cached market of a condition ID, along with its slug
+// and token entries
+func (r *MarketResolver) Invalidate(conditionID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.remove(strings.ToLower(conditionID))
+}
+
+// Clear drops all cached markets
+func (r *MarketResolver) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.markets = make(map[string]*UnifiedMarket)
+	r.slugs = make(map[string]string)
+	r.tokens = make(map[string]string)
+}
+
+// cached returns a fresh cached market by condition ID, or by a key of an index
+func (r *MarketResolver) cached(index map[string]string, key string) *UnifiedMarket {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	conditionID := key
+	if index != nil {
+		var ok bool
+		if conditionID, ok = index[key]; !ok {
+			return nil
+		}
+	}
+
+	market, ok := r.markets[conditionID]
+	if !ok || time.Since(market.FetchedAt) > r.ttl {
+		return nil
+	}
+	return market
+}
+
+// fetch resolves the first Gamma market returned for a query that matches
+// id. Gamma ignores filters it does not support, so the results are checked.
+func (r *MarketResolver) fetch(query *gamma.UpdatedMarketQuery, id string, matches func(*gamma.Market) bool) (*UnifiedMarket, error) {
+	markets, err := r.gamma.GetMarkets(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get market %s: %w", id, err)
+	}
+
+	for i := range markets {
+		if matches(&markets[i]) {
+			return r.resolve(&markets[i])
+		}
+	}
+	return nil, fmt.Errorf("market %s not found", id)
+}
+
+// remove drops a market and the slug and token entries pointing to it.
+// Callers must hold r.mu.
+func (r *MarketResolver) remove(conditionID string) {
+	market, ok := r.markets[conditionID]
+	if !ok {
+		return
+	}
+
+	delete(r.markets, conditionID)
+	if r.slugs[market.Slug] == conditionID {
+		delete(r.slugs, market.Slug)
+	}
+	for _, outcome := range market.Outcomes {
+		if r.tokens[outcome.TokenID] == conditionID {
+			delete(r.tokens, outcome.TokenID)
+		}
+	}
+}
+
+// store caches a market, first evicting every expired one so the cache only
+// holds markets resolved within the TTL
+func (r *MarketResolver) store(market *UnifiedMarket) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for conditionID, cached := range r.markets {
+		if time.Since(cached.FetchedAt) > r.ttl {
+			r.remove(conditionID)
+		}
+	}
+	r.remove(market.ConditionID)
+
+	r.markets[market.ConditionID] = market
+	r.slugs[market.Slug] = market.ConditionID
+	for _, outcome := range market.Outcomes {
+		r.tokens[outcome.TokenID] = market.ConditionID
+	}
+}
+
+// resolve joins a Gamma market with the CLOB and Data API and caches it
+func (r *MarketResolver) resolve(gammaMarket *gamma.Market) (*UnifiedMarket, error) {
+	if len(gammaMarket.ClobTokenIDs) != len(gammaMarket.Outcomes) {
+		return nil, fmt.Errorf("market %s has %d outcomes but %d token ids", gammaMarket.Slug, len(gammaMarket.Outcomes), len(gammaMarket.ClobTokenIDs))
+	}
+
+	market := &UnifiedMarket{
+		ID:          gammaMarket.ID,
+		ConditionID: strings.ToLower(gammaMarket.ConditionID),
+		Slug:        gammaMarket.Slug,
+		Question:    gammaMarket.Question,
+		Active:      gammaMarket.Active,
+		Closed:      gammaMarket.Closed,
+		Gamma:       gammaMarket,
+		FetchedAt:   time.Now(),
+	}
+	if gammaMarket.QuestionID != nil {
+		market.QuestionID = *gammaMarket.QuestionID
+	}
+	if gammaMarket.EndDate != nil {
+		market.EndDate = *gammaMarket.EndDate
+	}
+
+	bookParams := make([]types.BookParams, 0, len(gammaMarket.ClobTokenIDs))
+	for i, tokenID := range gammaMarket.ClobTokenIDs {
+		outcome := MarketOutcome{Outcome: gammaMarket.Outcomes[i], TokenID: tokenID}
+		if i < len(gammaMarket.OutcomePrices) {
+			price := gammaMarket.OutcomePrices[i]
+			outcome.Price = &price
+		}
+		market.Outcomes = append(market.Outcomes, outcome)
+		bookParams = append(bookParams, types.BookParams{TokenID: tokenID})
+	}
+
+	// Closed markets have no order books, so their trading parameters come
+	// from Gamma
+	if !market.Closed && len(bookParams) > 0 {
+		if err := r.joinBooks(market, bookParams); err != nil {
+			return nil, err
+		}
+
+		feeRate, err := r.clob.GetFeeRateBpsCached(bookParams[0].TokenID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get fee rate: %w", err)
+		}
+		market.FeeRateBps = feeRate
+	} else {
+		applyGammaParams(market, gammaMarket)
+	}
+
+	openInterest, err := r.data.GetOpenInterest(&data.OpenInterestQuery{Market: []string{market.ConditionID}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open interest: %w", err)
+	}
+	for _, oi := range openInterest {
+		if strings.EqualFold(oi.Market, market.ConditionID) {
+			value := oi.Value
+			market.OpenInterest = &value
+		}
+	}
+
+	r.store(market)
+	return market, nil
+}
+
+// applyGammaParams fills in the tick size, minimum order size and neg risk
+// flag of a market whose books are not fetched from its Gamma market
+func applyGammaParams(market *UnifiedMarket, gammaMarket *gamma.Market) {
+	if gammaMarket.OrderPriceMinTickSize != nil {
+		market.TickSize = types.TickSize(gammaMarket.OrderPriceMinTickSize.String())
+	}
+	if gammaMarket.OrderMinSize != nil {
+		market.MinOrderSize = *gammaMarket.OrderMinSize
+	}
+	if gammaMarket.NegRisk != nil {
+		market.NegRisk = *gammaMarket.NegRisk
+	}
+}
+
+// joinBooks fills in the book tops, tick size and neg risk flag of a market
+// and primes the CLOB client market cache with them
+func (r *MarketResolver) joinBooks(market *UnifiedMarket, bookParams []types.BookParams) error {
+	books, err := r.clob.GetOrderBooks(bookParams)
+	if err != nil {
+		return fmt.Errorf("failed to get order books: %w", err)
+	}
+
+	for _, book := range books {
+		outcome, ok := market.Outcome(book.AssetID)
+		if !ok {
+			continue
+		}
+
+		for _, bid := range book.Bids {
+			if outcome.BestBid == nil || bid.Price.GreaterThan(*outcome.BestBid) {
+				price := bid.Price
+				outcome.BestBid = &price
+			}
+		}
+		for _, ask := range book.Asks {
+			if outcome.BestAsk == nil || ask.Price.LessThan(*outcome.BestAsk) {
+				price := ask.Price
+				outcome.BestAsk = &price
+			}
+		}
+
+		market.TickSize = types.TickSize(book.TickSize)
+		market.MinOrderSize = book.MinOrderSize
+		market.NegRisk = book.NegRisk
+		r.clob.market.SetTickSize(book.AssetID, market.TickSize)
+		r.clob.market.SetNegRisk(book.AssetID, book.NegRisk)
+	}
+
+	if market.TickSize == "" {
+		return fmt.Errorf("no order book returned for market %s", market.ConditionID)
+	}
+	return nil
+}
@@ -0,0 +1,342 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lixvyang/polymarket-sdk-go/data"
+	"github.com/lixvyang/polymarket-sdk-go/gamma"
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+var (
+	openConditionID   = "0x" + strings.Repeat("a1", 32)
+	closedConditionID = "0x" + strings.Repeat("b2", 32)
+)
+
+// gammaMarkets are the markets served by the Gamma stand-in, in the string
+// encoded array format of the Gamma API
+var gammaMarkets = []map[string]any{
+	{
+		"id":            "501",
+		"question":      "Will it rain tomorrow?",
+		"conditionId":   openConditionID,
+		"slug":          "will-it-rain-tomorrow",
+		"outcomes":      `["Yes", "No"]`,
+		"outcomePrices": `["0.51", "0.49"]`,
+		"clobTokenIds":  `["101", "102"]`,
+		"active":        true,
+		"closed":        false,
+	},
+	{
+		"id":                    "502",
+		"question":              "Did it snow yesterday?",
+		"conditionId":           closedConditionID,
+		"slug":                  "did-it-snow-yesterday",
+		"outcomes":              `["Yes", "No"]`,
+		"outcomePrices":         `["1", "0"]`,
+		"clobTokenIds":          `["201", "202"]`,
+		"active":                true,
+		"closed":                true,
+		"orderPriceMinTickSize": 0.001,
+		"orderMinSize":          15,
+		"negRisk":               true,
+	},
+}
+
+// marketAPIs are stand-ins for the Gamma, CLOB and Data APIs of the resolver
+type marketAPIs struct {
+	gamma *httptest.Server
+	clob  *clobServer
+	data  *httptest.Server
+
+	mu            sync.Mutex
+	gammaRequests int
+	// ignoreFilters makes Gamma return every market, as it does for
+	// filters it does not support
+	ignoreFilters bool
+}
+
+func newMarketAPIs(t *testing.T) *marketAPIs {
+	t.Helper()
+
+	apis := &marketAPIs{clob: newCLOBServer(t)}
+
+	apis.gamma = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apis.mu.Lock()
+		apis.gammaRequests++
+		ignoreFilters := apis.ignoreFilters
+		apis.mu.Unlock()
+
+		if slug, ok := strings.CutPrefix(r.URL.Path, "/markets/slug/"); ok {
+			for _, market := range gammaMarkets {
+				if market["slug"] == slug {
+					writeJSON(w, market)
+					return
+				}
+			}
+			http.NotFound(w, r)
+			return
+		}
+
+		query := r.URL.Query()
+		markets := []map[string]any{}
+		for _, market := range gammaMarkets {
+			var tokens []string
+			json.Unmarshal([]byte(market["clobTokenIds"].(string)), &tokens)
+
+			matches := slices.Contains(query["condition_ids"], market["conditionId"].(string))
+			for _, token := range query["clob_token_ids"] {
+				matches = matches || slices.Contains(tokens, token)
+			}
+			if matches || ignoreFilters {
+				markets = append(markets, market)
+			}
+		}
+		writeJSON(w, markets)
+	}))
+	t.Cleanup(apis.gamma.Close)
+
+	apis.data = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var interest []data.OpenInterest
+		for _, market := range r.URL.Query()["market"] {
+			interest = append(interest, data.OpenInterest{Market: market, Value: types.MustDecimal("1234.5")})
+		}
+		writeJSON(w, interest)
+	}))
+	t.Cleanup(apis.data.Close)
+
+	apis.clob.handle("POST /books", func(w http.ResponseWriter, r *http.Request) {
+		var params []types.BookParams
+		json.NewDecoder(r.Body).Decode(&params)
+
+		var books []types.OrderBookSummary
+		for _, p := range params {
+			books = append(books, types.OrderBookSummary{
+				Market:  openConditionID,
+				AssetID: p.TokenID,
+				Bids: []types.OrderSummary{
+					{Price: types.MustDecimal("0.48"), Size: types.MustDecimal("100")},
+					{Price: types.MustDecimal("0.5"), Size: types.MustDecimal("20")},
+				},
+				Asks: []types.OrderSummary{
+					{Price: types.MustDecimal("0.55"), Size: types.MustDecimal("100")},
+					{Price: types.MustDecimal("0.52"), Size: types.MustDecimal("20")},
+				},
+				MinOrderSize: types.MustDecimal("5"),
+				TickSize:     "0.01",
+			})
+		}
+		writeJSON(w, books)
+	})
+	apis.clob.handle("GET /fee-rate", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]int{"base_fee": 200})
+	})
+
+	return apis
+}
+
+func (apis *marketAPIs) requests() int {
+	apis.mu.Lock()
+	defer apis.mu.Unlock()
+	return apis.gammaRequests
+}
+
+func newTestResolver(t *testing.T, apis *marketAPIs) *MarketResolver {
+	t.Helper()
+
+	resolver, err := NewMarketResolver(
+		newTestClobClient(t, apis.clob, ClientConfig{}),
+		gamma.NewGammaSDK(&gamma.GammaSDKConfig{BaseURL: apis.gamma.URL}),
+		data.NewDataSDK(&data.DataSDKConfig{BaseURL: apis.data.URL}),
+		nil,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resolver
+}
+
+// assertOpenMarket checks the join of the open market
+func assertOpenMarket(t *testing.T, market *UnifiedMarket) {
+	t.Helper()
+
+	if market.ID != "501" || market.ConditionID != openConditionID || market.Slug != "will-it-rain-tomorrow" || market.Closed {
+		t.Errorf("market = %s %s %s closed %v", market.ID, market.ConditionID, market.Slug, market.Closed)
+	}
+	if market.TickSize != "0.01" || market.MinOrderSize.String() != "5" || market.NegRisk || market.FeeRateBps != 200 {
+		t.Errorf("parameters = tick %s, min %s, neg risk %v, fee %d", market.TickSize, market.MinOrderSize, market.NegRisk, market.FeeRateBps)
+	}
+	if market.OpenInterest == nil || market.OpenInterest.String() != "1234.5" {
+		t.Errorf("open interest = %v, want 1234.5", market.OpenInterest)
+	}
+
+	yes, ok := market.Outcome("101")
+	if !ok || yes.Outcome != "Yes" {
+		t.Fatalf("outcome of 101 = %+v", yes)
+	}
+	if yes.Price.String() != "0.51" || yes.BestBid.String() != "0.5" || yes.BestAsk.String() != "0.52" {
+		t.Errorf("Yes = price %s, bid %s, ask %s", yes.Price, yes.BestBid, yes.BestAsk)
+	}
+	if no, ok := market.Outcome("102"); !ok || no.Outcome != "No" || no.Price.String() != "0.49" {
+		t.Errorf("outcome of 102 = %+v", no)
+	}
+}
+
+func TestMarketResolver(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+	}{
+		{"slug", "will-it-rain-tomorrow"},
+		{"condition ID", openConditionID},
+		{"upper case condition ID", "0x" + strings.ToUpper(openConditionID[2:])},
+		{"token ID", "102"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apis := newMarketAPIs(t)
+			resolver := newTestResolver(t, apis)
+
+			market, err := resolver.Resolve(tt.id)
+			if err != nil {
+				t.Fatalf("Resolve failed: %v", err)
+			}
+			assertOpenMarket(t, market)
+
+			// Every identifier of the market is now served from the cache
+			for _, id := range []string{"will-it-rain-tomorrow", openConditionID, "101", "102"} {
+				cached, err := resolver.Resolve(id)
+				if err != nil || cached != market {
+					t.Errorf("Resolve(%s) = %p, %v, want the cached market", id, cached, err)
+				}
+			}
+			if n := apis.requests(); n != 1 {
+				t.Errorf("%d Gamma requests, want 1", n)
+			}
+
+			// The CLOB market cache is primed with the book parameters
+			if tick, err := resolver.clob.GetTickSizeCached("101"); err != nil || tick != "0.01" {
+				t.Errorf("GetTickSizeCached = %s, %v", tick, err)
+			}
+			if n := apis.clob.count("GET /tick-size"); n != 0 {
+				t.Errorf("%d tick size requests, want the primed cache used", n)
+			}
+		})
+	}
+}
+
+func TestMarketResolverClosed(t *testing.T) {
+	apis := newMarketAPIs(t)
+	resolver := newTestResolver(t, apis)
+
+	market, err := resolver.Resolve("201")
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	// Closed markets have no books, so the parameters come from Gamma
+	if n := apis.clob.count("POST /books"); n != 0 {
+		t.Errorf("%d book requests for a closed market", n)
+	}
+	if !market.Closed || market.TickSize != "0.001" || market.MinOrderSize.String() != "15" || !market.NegRisk {
+		t.Errorf("market = closed %v, tick %s, min %s, neg risk %v", market.Closed, market.TickSize, market.MinOrderSize, market.NegRisk)
+	}
+	if yes, ok := market.Outcome("201"); !ok || yes.BestBid != nil || yes.BestAsk != nil || yes.Price.String() != "1" {
+		t.Errorf("outcome of 201 = %+v", yes)
+	}
+}
+
+func TestMarketResolverNotFound(t *testing.T) {
+	apis := newMarketAPIs(t)
+	resolver := newTestResolver(t, apis)
+
+	for _, id := range []string{"no-such-market", "999", "0x" + strings.Repeat("cc", 32)} {
+		if _, err := resolver.Resolve(id); err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Errorf("Resolve(%s) = %v, want not found", id, err)
+		}
+	}
+
+	// Markets Gamma returns for an unsupported filter are not mistaken for
+	// the requested one
+	apis.mu.Lock()
+	apis.ignoreFilters = true
+	apis.mu.Unlock()
+	for _, id := range []string{"999", "0x" + strings.Repeat("cc", 32)} {
+		if _, err := resolver.Resolve(id); err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Errorf("Resolve(%s) = %v, want not found", id, err)
+		}
+	}
+	if market, err := resolver.Resolve("202"); err != nil || market.ConditionID != closedConditionID {
+		t.Errorf("Resolve(202) = %v, want the closed market", err)
+	}
+}
+
+func TestMarketResolverExpiry(t *testing.T) {
+	apis := newMarketAPIs(t)
+	resolver := newTestResolver(t, apis)
+
+	// age makes a cached market older than the TTL
+	age := func(conditionID string) {
+		resolver.mu.Lock()
+		resolver.markets[conditionID].FetchedAt = time.Now().Add(-2 * resolver.ttl)
+		resolver.mu.Unlock()
+	}
+
+	first, err := resolver.Resolve("will-it-rain-tomorrow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	age(openConditionID)
+
+	second, err := resolver.Resolve("101")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second == first || apis.requests() != 2 {
+		t.Errorf("expired market served from the cache after %d Gamma requests", apis.requests())
+	}
+
+	// Storing a market evicts the expired ones with their slug and tokens
+	age(openConditionID)
+	if _, err := resolver.Resolve(closedConditionID); err != nil {
+		t.Fatal(err)
+	}
+	resolver.mu.RLock()
+	_, cached := resolver.markets[openConditionID]
+	_, slug := resolver.slugs["will-it-rain-tomorrow"]
+	_, token := resolver.tokens["101"]
+	sizes := []int{len(resolver.markets), len(resolver.slugs), len(resolver.tokens)}
+	resolver.mu.RUnlock()
+	if cached || slug || token {
+		t.Errorf("expired market still cached: market %v, slug %v, token %v", cached, slug, token)
+	}
+	if !slices.Equal(sizes, []int{1, 1, 2}) {
+		t.Errorf("cache sizes = %v, want [1 1 2]", sizes)
+	}
+
+	// Invalidate drops every entry of the market
+	resolver.Invalidate(strings.ToUpper(closedConditionID))
+	resolver.mu.RLock()
+	sizes = []int{len(resolver.markets), len(resolver.slugs), len(resolver.tokens)}
+	resolver.mu.RUnlock()
+	if !slices.Equal(sizes, []int{0, 0, 0}) {
+		t.Errorf("cache sizes after Invalidate = %v, want empty", sizes)
+	}
+	if _, err := resolver.Resolve("did-it-snow-yesterday"); err != nil || apis.requests() != 4 {
+		t.Errorf("Resolve after Invalidate = %v after %d Gamma requests, want a new request", err, apis.requests())
+	}
+}
+
+func TestNewMarketResolverRequiresClob(t *testing.T) {
+	if _, err := NewMarketResolver(nil, nil, nil, nil); err == nil {
+		t.Error("NewMarketResolver accepted a nil CLOB client")
+	}
+}
@@ -0,0 +1,382 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lixvyang/polymarket-sdk-go/auth"
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+const zeroAddress = "0x0000000000000000000000000000000000000000"
+
+// tokenDecimals is the number of decimals of USDC and outcome tokens
+const tokenDecimals = 6
+
+// roundingConfig holds the number of decimals allowed for each tick size
+var roundingConfig = map[types.TickSize]types.RoundConfig{
+	types.TickSize01:    {Price: 1, Size: 2, Amount: 3},
+	types.TickSize001:   {Price: 2, Size: 2, Amount: 4},
+	types.TickSize0001:  {Price: 3, Size: 2, Amount: 5},
+	types.TickSize00001: {Price: 4, Size: 2, Amount: 6},
+}
+
+// NonceSource returns the current exchange nonce of an order maker
+type NonceSource interface {
+	Nonce(ctx context.Context, maker common.Address, negRisk bool) (*big.Int, error)
+}
+
+// GetContractConfig returns the contract addresses the client signs for:
+// the configured ones, else those registered for the chain
+func (c *ClobClient) GetContractConfig() (*types.ContractConfig, error) {
+	if c.contracts != nil {
+		return c.contracts, nil
+	}
+	return types.GetContractConfig(c.chainID)
+}
+
+// GetSignatureType returns the signature type of the orders signed by the client
+func (c *ClobClient) GetSignatureType() types.SignatureType {
+	return c.signatureType
+}
+
+// GetFunderAddress returns the order maker: the funder address, or the wallet address for EOA accounts
+func (c *ClobClient) GetFunderAddress() string {
+	return c.funder.Hex()
+}
+
+// CreateOrder builds and signs a limit order. Tick size, neg risk and fee
+// rate are fetched from the CLOB (and cached) unless given in options.
+func (c *ClobClient) CreateOrder(userOrder *types.UserOrder, options *types.CreateOrderOptions) (*types.SignedOrder, error) {
+	if c.signer == nil {
+		return nil, fmt.Errorf("signer is required to sign orders")
+	}
+
+	tickSize, err := c.resolveTickSize(userOrder.TokenID, options)
+	if err != nil {
+		return nil, err
+	}
+
+	roundConfig, ok := roundingConfig[tickSize]
+	if !ok {
+		return nil, fmt.Errorf("unsupported tick size %s", tickSize)
+	}
+
+	tick := tickSize.Decimal()
+	maxPrice := types.NewDecimalFromInt(1).Sub(tick)
+	if userOrder.Price.LessThan(tick) || userOrder.Price.GreaterThan(maxPrice) {
+		return nil, fmt.Errorf("invalid price %s, min: %s - max: %s", userOrder.Price, tick, maxPrice)
+	}
+
+	var negRisk bool
+	if options != nil && options.NegRisk != nil {
+		negRisk = *options.NegRisk
+	} else {
+		negRisk, err = c.GetNegRiskCached(userOrder.TokenID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get neg risk: %w", err)
+		}
+	}
+
+	feeRateBps, err := c.resolveFeeRate(userOrder.TokenID, userOrder.FeeRateBps)
+	if err != nil {
+		return nil, err
+	}
+
+	makerAmount, takerAmount, err := orderAmounts(userOrder.Side, userOrder.Size, userOrder.Price, roundConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	taker := userOrder.Taker
+	if taker == "" {
+		taker = zeroAddress
+	}
+
+	var nonce, expiration int
+	if userOrder.Nonce != nil {
+		nonce = *userOrder.Nonce
+	} else if c.nonceSource != nil {
+		current, err := c.nonceSource.Nonce(context.Background(), c.funder, negRisk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get nonce: %w", err)
+		}
+		if !current.IsInt64() {
+			return nil, fmt.Errorf("nonce %s is out of range", current)
+		}
+		nonce = int(current.Int64())
+	}
+	if userOrder.Expiration != nil {
+		expiration = *userOrder.Expiration
+	}
+
+	order := &types.SignedOrder{
+		Salt:          strconv.FormatInt(rand.Int63n(1<<53), 10),
+		Maker:         c.funder.Hex(),
+		Signer:        c.signer.GetAddress().Hex(),
+		Taker:         taker,
+		TokenID:       userOrder.TokenID,
+		MakerAmount:   makerAmount,
+		TakerAmount:   takerAmount,
+		Expiration:    strconv.Itoa(expiration),
+		Nonce:         strconv.Itoa(nonce),
+		FeeRateBps:    strconv.Itoa(feeRateBps),
+		Side:          userOrder.Side,
+		SignatureType: c.signatureType,
+	}
+
+	contracts, err := c.GetContractConfig()
+	if err != nil {
+		return nil, err
+	}
+	exchange := contracts.ExchangeAddress(negRisk).Hex()
+
+	if err := auth.SignOrder(c.signer, order, int64(c.chainID), exchange); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// postOrderPayload is the wire format of a signed order
+type postOrderPayload struct {
+	Order struct {
+		Salt          int64  `json:"salt"`
+		Maker         string `json:"maker"`
+		Signer        string `json:"signer"`
+		Taker         string `json:"taker"`
+		TokenID       string `json:"tokenId"`
+		MakerAmount   string `json:"makerAmount"`
+		TakerAmount   string `json:"takerAmount"`
+		Expiration    string `json:"expiration"`
+		Nonce         string `json:"nonce"`
+		FeeRateBps    string `json:"feeRateBps"`
+		Side          string `json:"side"`
+		SignatureType int    `json:"signatureType"`
+		Signature     string `json:"signature"`
+	} `json:"order"`
+	Owner     string          `json:"owner"`
+	OrderType types.OrderType `json:"orderType"`
+}
+
+// PostOrder posts a signed order
+func (c *ClobClient) PostOrder(order *types.SignedOrder, orderType types.OrderType) (*types.OrderResponse, error) {
+	if c.GetApiCreds() == nil {
+		return nil, fmt.Errorf("API credentials are required")
+	}
+
+	salt, err := strconv.ParseInt(order.Salt, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order salt %q: %w", order.Salt, err)
+	}
+
+	if orderType == "" {
+		orderType = types.OrderTypeGTC
+	}
+
+	var payload postOrderPayload
+	payload.Order.Salt = salt
+	payload.Order.Maker = order.Maker
+	payload.Order.Signer = order.Signer
+	payload.Order.Taker = order.Taker
+	payload.Order.TokenID = order.TokenID
+	payload.Order.MakerAmount = order.MakerAmount.String()
+	payload.Order.TakerAmount = order.TakerAmount.String()
+	payload.Order.Expiration = order.Expiration
+	payload.Order.Nonce = order.Nonce
+	payload.Order.FeeRateBps = order.FeeRateBps
+	payload.Order.Side = string(order.Side)
+	payload.Order.SignatureType = int(order.SignatureType)
+	payload.Order.Signature = order.Signature
+	payload.OrderType = orderType
+
+	var result types.OrderResponse
+	err = c.withAuthRetry(func() error {
+		// The owner is the API key, which changes when credentials are refreshed
+		payload.Owner = c.GetApiCreds().Key
+
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal order: %w", err)
+		}
+
+		headers, err := c.createL2HeadersWithBuilder(&types.L2HeaderArgs{
+			Method:      "POST",
+			RequestPath: PostOrder,
+			Body:        string(body),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create L2 headers: %w", err)
+		}
+
+		return c.postJSONWithHeaders(PostOrder, headers, payload, &result)
+	})
+	return &result, err
+}
+
+// CreateAndPostOrder creates, signs and posts a limit order
+func (c *ClobClient) CreateAndPostOrder(userOrder *types.UserOrder, options *types.CreateOrderOptions, orderType types.OrderType) (*types.OrderResponse, error) {
+	order, err := c.CreateOrder(userOrder, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+
+	return c.PostOrder(order, orderType)
+}
+
+// GetTickSizeCached returns the tick size of a token, fetching it once
+func (c *ClobClient) GetTickSizeCached(tokenID string) (types.TickSize, error) {
+	if tickSize, ok := c.market.TickSize(tokenID); ok {
+		return tickSize, nil
+	}
+
+	tickSize, err := c.GetTickSize(tokenID)
+	if err != nil {
+		return "", err
+	}
+
+	c.market.SetTickSize(tokenID, tickSize)
+	return tickSize, nil
+}
+
+// GetNegRiskCached returns the neg risk flag of a token, fetching it once
+func (c *ClobClient) GetNegRiskCached(tokenID string) (bool, error) {
+	if negRisk, ok := c.market.NegRisk(tokenID); ok {
+		return negRisk, nil
+	}
+
+	negRisk, err := c.GetNegRisk(tokenID)
+	if err != nil {
+		return false, err
+	}
+
+	c.market.SetNegRisk(tokenID, negRisk)
+	return negRisk, nil
+}
+
+// GetFeeRateBpsCached returns the fee rate of a token, fetching it once
+func (c *ClobClient) GetFeeRateBpsCached(tokenID string) (int, error) {
+	if feeRate, ok := c.market.FeeRate(tokenID); ok {
+		return feeRate, nil
+	}
+
+	feeRate, err := c.GetFeeRateBps(tokenID)
+	if err != nil {
+		return 0, err
+	}
+
+	c.market.SetFeeRate(tokenID, feeRate)
+	return feeRate, nil
+}
+
+// ClearMarketCache forgets the cached tick sizes, neg risk flags and fee rates,
+// e.g. after a tick_size_change event
+func (c *ClobClient) ClearMarketCache() {
+	c.market.Clear()
+}
+
+// resolveTickSize returns the tick size to use, rejecting one finer than the market's
+func (c *ClobClient) resolveTickSize(tokenID string, options *types.CreateOrderOptions) (types.TickSize, error) {
+	minTickSize, err := c.GetTickSizeCached(tokenID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get tick size: %w", err)
+	}
+
+	if options == nil || options.TickSize == "" {
+		return minTickSize, nil
+	}
+
+	requested, err := types.ParseDecimal(string(options.TickSize))
+	if err != nil {
+		return "", fmt.Errorf("invalid tick size %q", options.TickSize)
+	}
+	minimum, err := types.ParseDecimal(string(minTickSize))
+	if err != nil {
+		return "", fmt.Errorf("invalid market tick size %q", minTickSize)
+	}
+	if requested.LessThan(minimum) {
+		return "", fmt.Errorf("invalid tick size (%s), minimum for the market is %s", options.TickSize, minTickSize)
+	}
+
+	return options.TickSize, nil
+}
+
+// resolveFeeRate returns the fee rate to sign, which must match the market's when it charges fees
+func (c *ClobClient) resolveFeeRate(tokenID string, userFeeRate *int) (int, error) {
+	marketFeeRate, err := c.GetFeeRateBpsCached(tokenID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get fee rate: %w", err)
+	}
+
+	if marketFeeRate > 0 && userFeeRate != nil && *userFeeRate != marketFeeRate {
+		return 0, fmt.Errorf("invalid user provided fee rate: (%d), fee rate for the market must be %d", *userFeeRate, marketFeeRate)
+	}
+
+	return marketFeeRate, nil
+}
+
+// orderAmounts converts a price and size to maker and taker amounts in token
+// decimals. A BUY pays USDC (maker) for shares (taker); a SELL the reverse.
+func orderAmounts(side types.Side, size, price types.Decimal, roundConfig types.RoundConfig) (*big.Int, *big.Int, error) {
+	priceDigits := int32(roundConfig.Price)
+	sizeDigits := int32(roundConfig.Size)
+	amountDigits := int32(roundConfig.Amount)
+
+	rawPrice := price.Round(priceDigits)
+	shares := size.Floor(sizeDigits)
+
+	amount := shares.Mul(rawPrice)
+	if amount.DecimalPlaces() > amountDigits {
+		amount = amount.Ceil(amountDigits + 4)
+		if amount.DecimalPlaces() > amountDigits {
+			amount = amount.Floor(amountDigits)
+		}
+	}
+
+	switch side {
+	case types.SideBuy:
+		return amount.Units(tokenDecimals), shares.Units(tokenDecimals), nil
+	case types.SideSell:
+		return shares.Units(tokenDecimals), amount.Units(tokenDecimals), nil
+	}
+	return nil, nil, fmt.Errorf("invalid order side %q", side)
+}
+
+// createL2HeadersWithBuilder creates L2 headers, adding builder headers when configured
+func (c *ClobClient) createL2HeadersWithBuilder(args *types.L2HeaderArgs) (interface{}, error) {
+	headers, err := c.createL2Headers(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if !c.builderConfig.IsValid() {
+		return headers, nil
+	}
+
+	var body *string
+	if args.Body != "" {
+		body = &args.Body
+	}
+
+	ts := time.Now().Unix()
+	timestamp, err := c.timestamp()
+	if err != nil {
+		return nil, err
+	}
+	if timestamp != nil {
+		ts = *timestamp
+	}
+
+	builderHeaders, err := c.builderConfig.GenerateBuilderHeadersAt(args.Method, args.RequestPath, body, ts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create builder headers: %w", err)
+	}
+
+	return auth.InjectBuilderHeaders(headers.(*types.L2PolyHeader), builderHeaders), nil
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lixvyang/polymarket-sdk-go/auth"
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// serveMarket answers the tick size and fee rate lookups of CreateOrder
+func serveMarket(server *clobServer) {
+	server.handle("GET /tick-size", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"minimum_tick_size": 0.01})
+	})
+	server.handle("GET /fee-rate", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"base_fee": 0})
+	})
+}
+
+func TestCreateOrderMakerAndSigner(t *testing.T) {
+	signer := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	funder := common.HexToAddress("0x00000000000000000000000000000000000000F1")
+
+	tests := []struct {
+		name          string
+		signatureType types.SignatureType
+		funder        string
+		negRisk       bool
+		wantMaker     common.Address
+	}{
+		{"eoa", types.SignatureTypeEOA, "", false, signer},
+		{"proxy wallet", types.SignatureTypePolyProxy, "", false, common.HexToAddress("0x365f0CA36Ae1f641E02fE3B7743673da42A13A70")},
+		{"gnosis safe", types.SignatureTypePolyGnosisSafe, "", false, common.HexToAddress("0xd93B25cb943D14d0d34FBaF01Fc93a0f8b5F6E47")},
+		{"explicit funder", types.SignatureTypePolyGnosisSafe, funder.Hex(), false, funder},
+		{"neg risk proxy wallet", types.SignatureTypePolyProxy, "", true, common.HexToAddress("0x365f0CA36Ae1f641E02fE3B7743673da42A13A70")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newCLOBServer(t)
+			serveMarket(server)
+			client := newTestClobClient(t, server, ClientConfig{SignatureType: tt.signatureType, FunderAddress: tt.funder})
+
+			order, err := client.CreateOrder(&types.UserOrder{
+				TokenID: "1234",
+				Price:   types.MustDecimal("0.5"),
+				Size:    types.MustDecimal("10"),
+				Side:    types.SideBuy,
+			}, &types.CreateOrderOptions{NegRisk: &tt.negRisk})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if common.HexToAddress(order.Maker) != tt.wantMaker {
+				t.Errorf("maker = %s, want %s", order.Maker, tt.wantMaker)
+			}
+			if common.HexToAddress(order.Signer) != signer {
+				t.Errorf("signer = %s, want %s", order.Signer, signer)
+			}
+			if order.SignatureType != tt.signatureType {
+				t.Errorf("signature type = %d, want %d", order.SignatureType, tt.signatureType)
+			}
+
+			// The signature is over the order as posted, for the exchange of
+			// the market, and recovers to the signing EOA
+			contracts, err := types.GetContractConfig(types.ChainPolygon)
+			if err != nil {
+				t.Fatal(err)
+			}
+			typedData, err := auth.OrderTypedData(order, int64(types.ChainPolygon), contracts.ExchangeAddress(tt.negRisk).Hex())
+			if err != nil {
+				t.Fatal(err)
+			}
+			hash, err := auth.HashTypedData(typedData)
+			if err != nil {
+				t.Fatal(err)
+			}
+			recovered, err := auth.RecoverAddress(hash, order.Signature)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if recovered != signer {
+				t.Errorf("recovered signer = %s, want %s", recovered, signer)
+			}
+		})
+	}
+}
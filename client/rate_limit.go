@@ -0,0 +1,36 @@
+package client
+
+import (
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimitedTransport waits for a shared limiter before each request
+type rateLimitedTransport struct {
+	base    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+// RoundTrip waits for the limiter, or for the request context to end, and
+// sends the request
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
+
+// withRateLimit returns a copy of client whose requests share limiter
+func withRateLimit(client *http.Client, limiter *rate.Limiter) *http.Client {
+	limited := *client
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	limited.Transport = &rateLimitedTransport{base: base, limiter: limiter}
+	return &limited
+}
@@ -2,8 +2,10 @@ package client
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"sync"
 	"time"
 
@@ -16,8 +18,26 @@ const (
 	pingInterval = 10 * time.Second
 )
 
+// WebSocketChannel identifies a CLOB WebSocket channel
+type WebSocketChannel string
+
+const (
+	// ChannelMarket streams public market data and needs no authentication
+	ChannelMarket WebSocketChannel = "market"
+	// ChannelUser streams the orders and trades of the authenticated user
+	ChannelUser WebSocketChannel = "user"
+)
+
 // WebSocketClientOptions configures the WebSocket client
 type WebSocketClientOptions struct {
+	// Base WebSocket URL (default wss://ws-subscriptions-clob.polymarket.com)
+	URL string
+
+	// Channel to connect to (default ChannelMarket). The user channel requires
+	// API credentials on the ClobClient, or a wallet to derive them; its
+	// messages are delivered through OnUnknown.
+	Channel WebSocketChannel
+
 	// Asset IDs to subscribe to
 	AssetIDs []string
 
@@ -38,6 +58,15 @@ type WebSocketClientOptions struct {
 
 	// Custom logger (if nil, uses default log.Logger)
 	Logger *log.Logger
+
+	// Optional recorder that receives every raw frame (see NewRecorder)
+	Recorder *Recorder
+
+	// Enable custom feature events (best_bid_ask, new_market, market_resolved)
+	CustomFeatures bool
+
+	// Optional hooks for exporting connection metrics
+	Metrics *WebSocketMetricsHooks
 }
 
 // MessageHandler is a callback function for handling messages
@@ -55,12 +84,28 @@ type TickSizeChangeMessageHandler func(msg *types.TickSizeChangeMessage)
 // LastTradePriceMessageHandler handles last trade price messages
 type LastTradePriceMessageHandler func(msg *types.LastTradePriceMessage)
 
+// BestBidAskMessageHandler handles best bid/ask messages
+type BestBidAskMessageHandler func(msg *types.BestBidAskMessage)
+
+// NewMarketMessageHandler handles new market messages
+type NewMarketMessageHandler func(msg *types.NewMarketMessage)
+
+// MarketResolvedMessageHandler handles market resolved messages
+type MarketResolvedMessageHandler func(msg *types.MarketResolvedMessage)
+
+// UnknownMessageHandler handles messages with an event type the SDK does not know
+type UnknownMessageHandler func(eventType types.EventType, raw []byte)
+
 // WebSocketCallbacks holds callback functions for different events
 type WebSocketCallbacks struct {
 	OnBook           BookMessageHandler
 	OnPriceChange    PriceChangeMessageHandler
 	OnTickSizeChange TickSizeChangeMessageHandler
 	OnLastTradePrice LastTradePriceMessageHandler
+	OnBestBidAsk     BestBidAskMessageHandler
+	OnNewMarket      NewMarketMessageHandler
+	OnMarketResolved MarketResolvedMessageHandler
+	OnUnknown        UnknownMessageHandler
 	OnMessage        MessageHandler
 	OnError          func(error)
 	OnConnect        func()
@@ -75,14 +120,17 @@ type WebSocketClient struct {
 	callbacks  *WebSocketCallbacks
 
 	conn              *websocket.Conn
-	pingTicker        *time.Ticker
+	pingStop          chan struct{}
 	reconnectTimer    *time.Timer
 	done              chan struct{}
 	reconnectAttempts int
 	isConnecting      bool
 	shouldReconnect   bool
 	mu                sync.RWMutex
+	writeMu           sync.Mutex
 	logger            *log.Logger
+	stats             *wsStats
+	creds             *types.ApiKeyCreds
 }
 
 // NewWebSocketClient creates a new WebSocket client
@@ -95,6 +143,12 @@ func NewWebSocketClient(clobClient *ClobClient, options *WebSocketClientOptions)
 	if options.AutoReconnect && options.ReconnectDelay == 0 {
 		options.ReconnectDelay = 5 * time.Second
 	}
+	if options.URL == "" {
+		options.URL = wsURL
+	}
+	if options.Channel == "" {
+		options.Channel = ChannelMarket
+	}
 
 	logger := options.Logger
 	if logger == nil {
@@ -108,6 +162,7 @@ func NewWebSocketClient(clobClient *ClobClient, options *WebSocketClientOptions)
 		done:            make(chan struct{}),
 		shouldReconnect: true,
 		logger:          logger,
+		stats:           newWSStats(),
 	}
 }
 
@@ -120,28 +175,39 @@ func (ws *WebSocketClient) On(callbacks *WebSocketCallbacks) *WebSocketClient {
 // Connect establishes the WebSocket connection
 func (ws *WebSocketClient) Connect() error {
 	ws.mu.Lock()
-	if ws.isConnecting || (ws.conn != nil && ws.IsConnected()) {
+	if ws.isConnecting || ws.conn != nil {
 		ws.mu.Unlock()
 		ws.log("Already connected or connecting")
 		return nil
 	}
 	ws.isConnecting = true
 	ws.shouldReconnect = true
+
+	// Reopen Wait after a Disconnect
+	select {
+	case <-ws.done:
+		ws.done = make(chan struct{})
+	default:
+	}
 	ws.mu.Unlock()
 
-	// Derive API credentials
-	apiKey, err := ws.clobClient.DeriveApiKey(nil)
-	if err != nil {
+	// Only the user channel is authenticated
+	if ws.options.Channel == ChannelUser {
+		creds, err := ws.userCredentials()
+		if err != nil {
+			ws.mu.Lock()
+			ws.isConnecting = false
+			ws.mu.Unlock()
+			return err
+		}
+
 		ws.mu.Lock()
-		ws.isConnecting = false
+		ws.creds = creds
 		ws.mu.Unlock()
-		return fmt.Errorf("failed to derive API key: %w", err)
 	}
 
-	ws.log("API key derived:", apiKey.Key)
-
 	// Create WebSocket connection
-	fullURL := fmt.Sprintf("%s/ws/market", wsURL)
+	fullURL := fmt.Sprintf("%s/ws/%s", strings.TrimRight(ws.options.URL, "/"), ws.options.Channel)
 	dialer := websocket.Dialer{}
 	conn, _, err := dialer.Dial(fullURL, nil)
 	if err != nil {
@@ -151,8 +217,11 @@ func (ws *WebSocketClient) Connect() error {
 		return fmt.Errorf("failed to connect to WebSocket: %w", err)
 	}
 
+	pingStop := make(chan struct{})
+
 	ws.mu.Lock()
 	ws.conn = conn
+	ws.pingStop = pingStop
 	ws.isConnecting = false
 	ws.reconnectAttempts = 0
 	ws.mu.Unlock()
@@ -165,8 +234,8 @@ func (ws *WebSocketClient) Connect() error {
 	}
 
 	// Start handlers
-	go ws.handleMessages()
-	go ws.pingLoop()
+	go ws.handleMessages(conn)
+	go ws.pingLoop(conn, pingStop)
 
 	if ws.callbacks.OnConnect != nil {
 		ws.callbacks.OnConnect()
@@ -188,24 +257,50 @@ func (ws *WebSocketClient) Disconnect() {
 		ws.conn.Close()
 		ws.conn = nil
 	}
+	ws.closeDone()
 	ws.mu.Unlock()
 }
 
-// Subscribe adds asset IDs to the subscription
+// Subscribe adds asset IDs to the subscription and, if connected, asks the
+// server to stream the ones not already subscribed
 func (ws *WebSocketClient) Subscribe(assetIDs []string) error {
 	ws.mu.Lock()
-	ws.options.AssetIDs = append(ws.options.AssetIDs, assetIDs...)
+	subscribed := make(map[string]struct{}, len(ws.options.AssetIDs)+len(assetIDs))
+	for _, id := range ws.options.AssetIDs {
+		subscribed[id] = struct{}{}
+	}
+
+	var newIDs []string
+	for _, id := range assetIDs {
+		if _, ok := subscribed[id]; ok {
+			continue
+		}
+		subscribed[id] = struct{}{}
+		newIDs = append(newIDs, id)
+	}
+	ws.options.AssetIDs = append(ws.options.AssetIDs, newIDs...)
 	ws.mu.Unlock()
 
+	if len(newIDs) > 0 && ws.IsConnected() {
+		return ws.sendOperation("subscribe", newIDs)
+	}
+
+	return nil
+}
+
+// Unsubscribe removes asset IDs from the subscription and, if connected,
+// tells the server to stop streaming them
+func (ws *WebSocketClient) Unsubscribe(assetIDs []string) error {
+	ws.removeAssetIDs(assetIDs)
+
 	if ws.IsConnected() {
-		return ws.sendSubscription()
+		return ws.sendOperation("unsubscribe", assetIDs)
 	}
 
 	return nil
 }
 
-// Unsubscribe removes asset IDs from the subscription
-func (ws *WebSocketClient) Unsubscribe(assetIDs []string) {
+func (ws *WebSocketClient) removeAssetIDs(assetIDs []string) {
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
 
@@ -235,44 +330,119 @@ func (ws *WebSocketClient) IsConnected() bool {
 
 // Wait blocks until the WebSocket is disconnected
 func (ws *WebSocketClient) Wait() {
-	<-ws.done
+	ws.mu.RLock()
+	done := ws.done
+	ws.mu.RUnlock()
+	<-done
+}
+
+// closeDone releases Wait. Callers must hold ws.mu.
+func (ws *WebSocketClient) closeDone() {
+	select {
+	case <-ws.done:
+	default:
+		close(ws.done)
+	}
+}
+
+// userCredentials returns the API credentials of the ClobClient, loading or
+// deriving them when the client has a signer but no credentials
+func (ws *WebSocketClient) userCredentials() (*types.ApiKeyCreds, error) {
+	if ws.clobClient == nil {
+		return nil, fmt.Errorf("a CLOB client is required for the user channel")
+	}
+
+	creds, err := ws.clobClient.InitApiCreds()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize API credentials: %w", err)
+	}
+
+	return creds, nil
 }
 
 func (ws *WebSocketClient) sendSubscription() error {
 	ws.mu.RLock()
 	conn := ws.conn
+	channel := ws.options.Channel
 	assetIDs := ws.options.AssetIDs
+	markets := ws.options.Markets
+	customFeatures := ws.options.CustomFeatures
+	creds := ws.creds
 	ws.mu.RUnlock()
 
 	if conn == nil {
 		return fmt.Errorf("not connected")
 	}
 
+	if channel == ChannelUser {
+		message := map[string]interface{}{
+			"auth": map[string]string{
+				"apiKey":     creds.Key,
+				"secret":     creds.Secret,
+				"passphrase": creds.Passphrase,
+			},
+			"markets": markets,
+			"type":    string(ChannelUser),
+		}
+
+		ws.log("Sending user subscription:", markets)
+		return ws.writeJSON(conn, message)
+	}
+
 	message := map[string]interface{}{
 		"assets_ids": assetIDs,
-		"type":       "market",
+		"type":       string(ChannelMarket),
+	}
+	if customFeatures {
+		message["custom_feature_enabled"] = true
 	}
 
 	ws.log("Sending subscription:", assetIDs)
-	return conn.WriteJSON(message)
+	return ws.writeJSON(conn, message)
 }
 
-func (ws *WebSocketClient) handleMessages() {
+// sendOperation sends a dynamic subscribe/unsubscribe request on an open connection
+func (ws *WebSocketClient) sendOperation(operation string, assetIDs []string) error {
+	ws.mu.RLock()
+	conn := ws.conn
+	ws.mu.RUnlock()
+
+	if conn == nil {
+		return fmt.Errorf("not connected")
+	}
+
+	message := map[string]interface{}{
+		"assets_ids": assetIDs,
+		"operation":  operation,
+	}
+
+	ws.log("Sending", operation+":", assetIDs)
+	return ws.writeJSON(conn, message)
+}
+
+// writeJSON serializes writes, since a websocket connection supports only one
+// concurrent writer
+func (ws *WebSocketClient) writeJSON(conn *websocket.Conn, v interface{}) error {
+	ws.writeMu.Lock()
+	defer ws.writeMu.Unlock()
+	return conn.WriteJSON(v)
+}
+
+func (ws *WebSocketClient) writeMessage(conn *websocket.Conn, messageType int, data []byte) error {
+	ws.writeMu.Lock()
+	defer ws.writeMu.Unlock()
+	return conn.WriteMessage(messageType, data)
+}
+
+func (ws *WebSocketClient) handleMessages(conn *websocket.Conn) {
 	defer func() {
 		ws.log("Message handler stopped")
-		ws.handleDisconnect(websocket.CloseNormalClosure, "Connection closed")
+		ws.handleDisconnect(conn, websocket.CloseNormalClosure, "Connection closed")
 	}()
 
 	for {
-		ws.mu.RLock()
-		conn := ws.conn
-		ws.mu.RUnlock()
-
-		if conn == nil {
-			return
-		}
-
 		messageType, message, err := conn.ReadMessage()
+		receivedAt := time.Now()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
 				ws.handleError(fmt.Errorf("WebSocket error: %w", err))
@@ -280,40 +450,56 @@ func (ws *WebSocketClient) handleMessages() {
 			return
 		}
 
+		ws.recordFrame(len(message))
+
 		if messageType == websocket.TextMessage {
 			// Handle PONG
 			if string(message) == "PONG" {
+				ws.recordPong(receivedAt)
 				ws.log("Received PONG")
 				continue
 			}
 
-			ws.processMessage(message)
+			if ws.options.Recorder != nil {
+				if err := ws.options.Recorder.Record(receivedAt, message); err != nil {
+					ws.handleError(fmt.Errorf("failed to record message: %w", err))
+				}
+			}
+
+			ws.processMessage(message, receivedAt)
 		}
 	}
 }
 
-func (ws *WebSocketClient) processMessage(data []byte) {
+func (ws *WebSocketClient) processMessage(data []byte, receivedAt time.Time) {
 	// Try to parse as array first
 	var messages []json.RawMessage
 	if err := json.Unmarshal(data, &messages); err == nil {
 		// It's an array
 		for _, msgData := range messages {
-			ws.parseAndDispatch(msgData)
+			ws.parseAndDispatch(msgData, receivedAt)
 		}
 	} else {
 		// It's a single message
-		ws.parseAndDispatch(data)
+		ws.parseAndDispatch(data, receivedAt)
 	}
 }
 
-func (ws *WebSocketClient) parseAndDispatch(data []byte) {
+func (ws *WebSocketClient) parseAndDispatch(data []byte, receivedAt time.Time) {
 	msg, err := types.ParseMarketChannelMessage(data)
+	if errors.Is(err, types.ErrUnknownEventType) {
+		ws.handleUnknown(data, receivedAt)
+		return
+	}
 	if err != nil {
+		ws.recordParseError(err)
 		ws.handleError(fmt.Errorf("failed to parse message: %w", err))
 		ws.log("Raw message:", string(data))
 		return
 	}
 
+	ws.recordMessage(msg.GetEventType(), messageTimestamp(msg), receivedAt)
+
 	// Call specific handlers based on message type
 	switch msg.GetEventType() {
 	case types.EventTypeBook:
@@ -332,6 +518,18 @@ func (ws *WebSocketClient) parseAndDispatch(data []byte) {
 		if ltMsg, ok := types.AsLastTradePriceMessage(msg); ok && ws.callbacks.OnLastTradePrice != nil {
 			ws.callbacks.OnLastTradePrice(ltMsg)
 		}
+	case types.EventTypeBestBidAsk:
+		if bbaMsg, ok := types.AsBestBidAskMessage(msg); ok && ws.callbacks.OnBestBidAsk != nil {
+			ws.callbacks.OnBestBidAsk(bbaMsg)
+		}
+	case types.EventTypeNewMarket:
+		if nmMsg, ok := types.AsNewMarketMessage(msg); ok && ws.callbacks.OnNewMarket != nil {
+			ws.callbacks.OnNewMarket(nmMsg)
+		}
+	case types.EventTypeMarketResolved:
+		if mrMsg, ok := types.AsMarketResolvedMessage(msg); ok && ws.callbacks.OnMarketResolved != nil {
+			ws.callbacks.OnMarketResolved(mrMsg)
+		}
 	}
 
 	// Call general message handler
@@ -340,30 +538,43 @@ func (ws *WebSocketClient) parseAndDispatch(data []byte) {
 	}
 }
 
-func (ws *WebSocketClient) pingLoop() {
-	ws.mu.Lock()
-	ws.pingTicker = time.NewTicker(pingInterval)
-	ticker := ws.pingTicker
-	ws.mu.Unlock()
+// handleUnknown passes messages with an unknown event type to OnUnknown
+// instead of reporting them as errors
+func (ws *WebSocketClient) handleUnknown(data []byte, receivedAt time.Time) {
+	var eventTypeWrapper struct {
+		EventType types.EventType `json:"event_type"`
+		Timestamp string          `json:"timestamp"`
+	}
+	json.Unmarshal(data, &eventTypeWrapper)
+
+	ws.recordMessage(eventTypeWrapper.EventType, eventTypeWrapper.Timestamp, receivedAt)
+
+	if ws.callbacks.OnUnknown != nil {
+		ws.callbacks.OnUnknown(eventTypeWrapper.EventType, data)
+		return
+	}
 
+	ws.log("Unhandled event type:", eventTypeWrapper.EventType)
+}
+
+// pingLoop keeps conn alive until stop is closed by cleanup
+func (ws *WebSocketClient) pingLoop(conn *websocket.Conn, stop chan struct{}) {
+	ticker := time.NewTicker(pingInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-ws.done:
+		case <-stop:
 			return
 		case <-ticker.C:
-			ws.mu.RLock()
-			conn := ws.conn
-			ws.mu.RUnlock()
-
-			if conn != nil {
-				if err := conn.WriteMessage(websocket.TextMessage, []byte("PING")); err != nil {
-					ws.handleError(fmt.Errorf("failed to send ping: %w", err))
-					return
-				}
-				ws.log("Sent PING")
+			// Record the send time first, as the PONG can be read
+			// before the write returns
+			ws.recordPingSent(time.Now())
+			if err := ws.writeMessage(conn, websocket.TextMessage, []byte("PING")); err != nil {
+				ws.handleError(fmt.Errorf("failed to send ping: %w", err))
+				return
 			}
+			ws.log("Sent PING")
 		}
 	}
 }
@@ -376,19 +587,34 @@ func (ws *WebSocketClient) handleError(err error) {
 	}
 }
 
-func (ws *WebSocketClient) handleDisconnect(code int, reason string) {
-	ws.cleanup()
+// handleDisconnect releases conn once its reader stops, so that Connect can
+// dial again, and schedules a reconnect if enabled. A conn already replaced
+// or closed by Disconnect only reports OnDisconnect.
+func (ws *WebSocketClient) handleDisconnect(conn *websocket.Conn, code int, reason string) {
+	ws.mu.Lock()
+	current := ws.conn == conn
+	if current {
+		ws.conn.Close()
+		ws.conn = nil
+		ws.stopPing()
+	}
+	ws.mu.Unlock()
 
 	if ws.callbacks.OnDisconnect != nil {
 		ws.callbacks.OnDisconnect(code, reason)
 	}
+	if !current {
+		return
+	}
 
-	ws.mu.RLock()
-	shouldReconnect := ws.shouldReconnect
-	autoReconnect := ws.options.AutoReconnect
-	ws.mu.RUnlock()
+	ws.mu.Lock()
+	reconnect := ws.shouldReconnect && ws.options.AutoReconnect
+	if !reconnect {
+		ws.closeDone()
+	}
+	ws.mu.Unlock()
 
-	if shouldReconnect && autoReconnect {
+	if reconnect {
 		ws.scheduleReconnect()
 	}
 }
@@ -407,6 +633,7 @@ func (ws *WebSocketClient) scheduleReconnect() {
 	ws.mu.Unlock()
 
 	ws.log(fmt.Sprintf("Scheduling reconnect attempt %d...", attempt))
+	ws.recordReconnect(attempt)
 
 	if ws.callbacks.OnReconnect != nil {
 		ws.callbacks.OnReconnect(attempt)
@@ -426,10 +653,7 @@ func (ws *WebSocketClient) cleanup() {
 	ws.mu.Lock()
 	defer ws.mu.Unlock()
 
-	if ws.pingTicker != nil {
-		ws.pingTicker.Stop()
-		ws.pingTicker = nil
-	}
+	ws.stopPing()
 
 	if ws.reconnectTimer != nil {
 		ws.reconnectTimer.Stop()
@@ -437,6 +661,14 @@ func (ws *WebSocketClient) cleanup() {
 	}
 }
 
+// stopPing ends the ping loop of the current connection. Callers must hold ws.mu.
+func (ws *WebSocketClient) stopPing() {
+	if ws.pingStop != nil {
+		close(ws.pingStop)
+		ws.pingStop = nil
+	}
+}
+
 func (ws *WebSocketClient) log(args ...interface{}) {
 	if ws.options.Debug {
 		ws.logger.Println(append([]interface{}{"[PolymarketWebSocket]"}, args...)...)
@@ -0,0 +1,459 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// wsServer is a stand-in for the CLOB WebSocket server. It records every
+// request it receives and tracks the assets each connection is subscribed to.
+type wsServer struct {
+	*httptest.Server
+
+	mu    sync.Mutex
+	conns []*wsServerConn
+}
+
+// wsServerConn is the server side of a single connection
+type wsServerConn struct {
+	path string
+	conn *websocket.Conn
+
+	mu       sync.Mutex
+	writeMu  sync.Mutex
+	requests []map[string]any
+	assets   map[string]bool
+	closed   bool
+}
+
+func newWSServer(t *testing.T) *wsServer {
+	t.Helper()
+
+	s := &wsServer{}
+	upgrader := websocket.Upgrader{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+
+		c := &wsServerConn{path: r.URL.Path, conn: conn, assets: make(map[string]bool)}
+		s.mu.Lock()
+		s.conns = append(s.conns, c)
+		s.mu.Unlock()
+
+		c.serve()
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// URL returns the WebSocket URL of the server
+func (s *wsServer) URL() string {
+	return "ws" + strings.TrimPrefix(s.Server.URL, "http")
+}
+
+// connections returns the connections accepted so far
+func (s *wsServer) connections() []*wsServerConn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*wsServerConn(nil), s.conns...)
+}
+
+// open returns the connections the client has not closed
+func (s *wsServer) open() []*wsServerConn {
+	var open []*wsServerConn
+	for _, c := range s.connections() {
+		if !c.isClosed() {
+			open = append(open, c)
+		}
+	}
+	return open
+}
+
+func (c *wsServerConn) serve() {
+	defer func() {
+		c.mu.Lock()
+		c.closed = true
+		c.mu.Unlock()
+	}()
+
+	for {
+		_, message, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if string(message) == "PING" {
+			c.send("PONG")
+			continue
+		}
+
+		var request map[string]any
+		if err := json.Unmarshal(message, &request); err != nil {
+			continue
+		}
+
+		c.mu.Lock()
+		c.requests = append(c.requests, request)
+		ids, _ := request["assets_ids"].([]any)
+		for _, id := range ids {
+			switch request["operation"] {
+			case "unsubscribe":
+				delete(c.assets, id.(string))
+			default:
+				c.assets[id.(string)] = true
+			}
+		}
+		c.mu.Unlock()
+	}
+}
+
+// send writes a text frame to the client
+func (c *wsServerConn) send(message string) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	return c.conn.WriteMessage(websocket.TextMessage, []byte(message))
+}
+
+// close closes the connection from the server side
+func (c *wsServerConn) close() {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	c.conn.Close()
+}
+
+func (c *wsServerConn) received() []map[string]any {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]map[string]any(nil), c.requests...)
+}
+
+func (c *wsServerConn) subscribed() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ids := make([]string, 0, len(c.assets))
+	for id := range c.assets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (c *wsServerConn) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+// eventually fails the test if cond does not hold within two seconds
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// requestJSON re-encodes a recorded request for comparison
+func requestJSON(t *testing.T, request map[string]any) string {
+	t.Helper()
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestWebSocketClientSubscribe(t *testing.T) {
+	server := newWSServer(t)
+	ws := NewWebSocketClient(nil, &WebSocketClientOptions{URL: server.URL(), AssetIDs: []string{"1"}})
+	if err := ws.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Disconnect()
+
+	if err := ws.Subscribe([]string{"1", "2", "2"}); err != nil {
+		t.Fatal(err)
+	}
+	// Already subscribed, so nothing is sent
+	if err := ws.Subscribe([]string{"2", "1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ws.Subscribe([]string{"3"}); err != nil {
+		t.Fatal(err)
+	}
+
+	eventually(t, "the connection", func() bool { return len(server.connections()) == 1 })
+	conn := server.connections()[0]
+	eventually(t, "three requests", func() bool { return len(conn.received()) >= 3 })
+
+	if conn.path != "/ws/market" {
+		t.Errorf("path = %s, want /ws/market", conn.path)
+	}
+
+	want := []string{
+		`{"assets_ids":["1"],"type":"market"}`,
+		`{"assets_ids":["2"],"operation":"subscribe"}`,
+		`{"assets_ids":["3"],"operation":"subscribe"}`,
+	}
+	received := conn.received()
+	if len(received) != len(want) {
+		t.Fatalf("received %d requests, want %d", len(received), len(want))
+	}
+	for i, request := range received {
+		if got := requestJSON(t, request); got != want[i] {
+			t.Errorf("request %d = %s, want %s", i, got, want[i])
+		}
+	}
+
+	if got := strings.Join(ws.options.AssetIDs, ","); got != "1,2,3" {
+		t.Errorf("AssetIDs = %s, want 1,2,3", got)
+	}
+}
+
+func TestWebSocketClientDisconnect(t *testing.T) {
+	server := newWSServer(t)
+	ws := NewWebSocketClient(nil, &WebSocketClientOptions{URL: server.URL(), AssetIDs: []string{"1"}})
+
+	for i := 0; i < 2; i++ {
+		if err := ws.Connect(); err != nil {
+			t.Fatal(err)
+		}
+		ws.mu.RLock()
+		pingStop, done := ws.pingStop, ws.done
+		ws.mu.RUnlock()
+
+		select {
+		case <-done:
+			t.Fatalf("connect %d: Wait released while connected", i)
+		default:
+		}
+
+		ws.Disconnect()
+		ws.Wait()
+
+		select {
+		case <-pingStop:
+		default:
+			t.Fatalf("connect %d: ping loop not stopped", i)
+		}
+		if ws.IsConnected() {
+			t.Fatalf("connect %d: still connected", i)
+		}
+	}
+
+	eventually(t, "both connections closed", func() bool {
+		return len(server.connections()) == 2 && len(server.open()) == 0
+	})
+}
+
+func TestWebSocketClientServerClose(t *testing.T) {
+	server := newWSServer(t)
+	ws := NewWebSocketClient(nil, &WebSocketClientOptions{URL: server.URL(), AssetIDs: []string{"1"}})
+
+	disconnected := make(chan struct{})
+	ws.On(&WebSocketCallbacks{OnDisconnect: func(int, string) { close(disconnected) }})
+	if err := ws.Connect(); err != nil {
+		t.Fatal(err)
+	}
+
+	eventually(t, "the connection", func() bool { return len(server.connections()) == 1 })
+	server.connections()[0].close()
+
+	// Without AutoReconnect the client is done once the server closes
+	<-disconnected
+	ws.Wait()
+	if ws.IsConnected() {
+		t.Error("still connected after the server closed")
+	}
+}
+
+func TestWebSocketClientDispatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		message   string
+		noUnknown bool
+		want      []string
+		// parseErrors is 1 when the message is reported as an error
+		parseErrors uint64
+	}{
+		{
+			name: "best bid ask",
+			message: `{"event_type":"best_bid_ask","asset_id":"1","market":"0x1","best_bid":"0.49",` +
+				`"best_ask":"0.51","spread":"0.02","timestamp":"1700000000000"}`,
+			want: []string{"best_bid_ask 1", "message best_bid_ask"},
+		},
+		{
+			name: "new market",
+			message: `{"event_type":"new_market","id":"9","market":"0x1","assets_ids":["1","2"],` +
+				`"outcomes":["Yes","No"],"timestamp":"1700000000000"}`,
+			want: []string{"new_market 0x1", "message new_market"},
+		},
+		{
+			name: "market resolved",
+			message: `{"event_type":"market_resolved","id":"9","market":"0x1","assets_ids":["1","2"],` +
+				`"winning_asset_id":"1","winning_outcome":"Yes","timestamp":"1700000000000"}`,
+			want: []string{"market_resolved 0x1 1", "message market_resolved"},
+		},
+		{
+			name:    "unknown event",
+			message: `{"event_type":"future_event","timestamp":"1700000000000","payload":{"a":1}}`,
+			want:    []string{`unknown future_event {"event_type":"future_event","timestamp":"1700000000000","payload":{"a":1}}`},
+		},
+		{
+			name:    "missing event type",
+			message: `{"payload":1}`,
+			want:    []string{`unknown  {"payload":1}`},
+		},
+		{
+			name:      "unknown event without OnUnknown",
+			message:   `{"event_type":"future_event"}`,
+			noUnknown: true,
+		},
+		{
+			name:        "invalid known event",
+			message:     `{"event_type":"new_market","market":"0x1"}`,
+			want:        []string{"error"},
+			parseErrors: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			callbacks := &WebSocketCallbacks{
+				OnBestBidAsk: func(msg *types.BestBidAskMessage) {
+					got = append(got, "best_bid_ask "+msg.AssetID)
+				},
+				OnNewMarket: func(msg *types.NewMarketMessage) {
+					got = append(got, "new_market "+msg.Market)
+				},
+				OnMarketResolved: func(msg *types.MarketResolvedMessage) {
+					got = append(got, "market_resolved "+msg.Market+" "+msg.WinningAssetID)
+				},
+				OnMessage: func(msg types.MarketChannelMessage) {
+					got = append(got, "message "+string(msg.GetEventType()))
+				},
+				OnError: func(err error) { got = append(got, "error") },
+			}
+			if !tt.noUnknown {
+				callbacks.OnUnknown = func(eventType types.EventType, raw []byte) {
+					got = append(got, "unknown "+string(eventType)+" "+string(raw))
+				}
+			}
+			ws := NewWebSocketClient(nil, nil).On(callbacks)
+
+			ws.parseAndDispatch([]byte(tt.message), time.Now())
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("callbacks = %q, want %q", got, tt.want)
+			}
+			if errs := ws.Stats().ParseErrors; errs != tt.parseErrors {
+				t.Errorf("parse errors = %d, want %d", errs, tt.parseErrors)
+			}
+		})
+	}
+}
+
+func TestWebSocketClientMarketWithoutCredentials(t *testing.T) {
+	tests := []struct {
+		name   string
+		signer bool
+	}{
+		{"public client", false},
+		{"signer without credentials", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clob := newCLOBServer(t)
+			config := &ClientConfig{Host: clob.URL, ChainID: types.ChainPolygon}
+			if tt.signer {
+				config.PrivateKey = testPrivateKey
+			}
+			clobClient, err := NewClobClient(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if clobClient.GetApiCreds() != nil {
+				t.Fatal("client has API credentials")
+			}
+
+			server := newWSServer(t)
+			ws := NewWebSocketClient(clobClient, &WebSocketClientOptions{URL: server.URL() + "/", AssetIDs: []string{"1"}})
+			if err := ws.Connect(); err != nil {
+				t.Fatal(err)
+			}
+			defer ws.Disconnect()
+
+			eventually(t, "the subscription", func() bool {
+				conns := server.connections()
+				return len(conns) == 1 && len(conns[0].received()) == 1
+			})
+			conn := server.connections()[0]
+			if conn.path != "/ws/market" {
+				t.Errorf("path = %s, want /ws/market", conn.path)
+			}
+			if got, want := requestJSON(t, conn.received()[0]), `{"assets_ids":["1"],"type":"market"}`; got != want {
+				t.Errorf("subscription = %s, want %s", got, want)
+			}
+
+			// The market channel never creates or derives an API key
+			if n := clob.count("POST /auth/api-key") + clob.count("GET /auth/derive-api-key"); n != 0 {
+				t.Errorf("made %d API key requests", n)
+			}
+			if clobClient.GetApiCreds() != nil {
+				t.Error("connecting set API credentials")
+			}
+		})
+	}
+}
+
+func TestWebSocketClientUserChannelRequiresCredentials(t *testing.T) {
+	clob := newCLOBServer(t)
+	public, err := NewClobClient(&ClientConfig{Host: clob.URL, ChainID: types.ChainPolygon})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		clobClient *ClobClient
+		want       string
+	}{
+		{"no CLOB client", nil, "a CLOB client is required for the user channel"},
+		{"no signer", public, "signer is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newWSServer(t)
+			ws := NewWebSocketClient(tt.clobClient, &WebSocketClientOptions{URL: server.URL(), Channel: ChannelUser})
+
+			err := ws.Connect()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("Connect error = %v, want %q", err, tt.want)
+			}
+			if n := len(server.connections()); n != 0 {
+				t.Errorf("dialed %d connections without credentials", n)
+			}
+		})
+	}
+}
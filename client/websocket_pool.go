@@ -0,0 +1,588 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+const defaultMaxAssetsPerConnection = 500
+
+// WebSocketPoolOptions configures the WebSocket pool
+type WebSocketPoolOptions struct {
+	// Asset IDs to subscribe to
+	AssetIDs []string
+
+	// Maximum number of asset IDs held by a single connection (default 500)
+	MaxAssetsPerConnection int
+
+	// Maximum number of connections (0 = unlimited)
+	MaxConnections int
+
+	// Options applied to every pooled connection (AssetIDs is ignored)
+	Connection WebSocketClientOptions
+}
+
+// ConnectionHealth reports the state of a single pooled connection
+type ConnectionHealth struct {
+	ID            int
+	Connected     bool
+	AssetCount    int
+	Messages      uint64
+	Errors        uint64
+	Reconnects    int
+	ConnectedAt   time.Time
+	LastMessageAt time.Time
+	LastError     error
+	Stats         WebSocketStats
+}
+
+// poolShard is a single connection of the pool and the assets assigned to it
+type poolShard struct {
+	id     int
+	client *WebSocketClient
+	assets map[string]struct{}
+
+	healthMu sync.Mutex
+	health   ConnectionHealth
+}
+
+// WebSocketPool shards asset subscriptions across multiple WebSocket
+// connections and merges their messages into a single callback stream
+type WebSocketPool struct {
+	clobClient *ClobClient
+	options    *WebSocketPoolOptions
+	callbacks  *WebSocketCallbacks
+
+	shards      []*poolShard
+	assignments map[string]*poolShard
+	nextShardID int
+	connected   bool
+	mu          sync.Mutex
+
+	// opMu serializes pool operations, so the network I/O of one plan
+	// completes before the next plan is computed
+	opMu sync.Mutex
+
+	// dispatchMu serializes callbacks so handlers never run concurrently
+	dispatchMu sync.Mutex
+	done       chan struct{}
+	closeOnce  sync.Once
+}
+
+// poolAction is a network operation on a single pooled connection
+type poolAction int
+
+const (
+	actionSubscribe poolAction = iota
+	actionUnsubscribe
+	actionConnect
+	actionDisconnect
+)
+
+// poolOp is one step of a plan computed under p.mu and run outside it
+type poolOp struct {
+	shard  *poolShard
+	action poolAction
+	ids    []string
+}
+
+// NewWebSocketPool creates a new WebSocket pool
+func NewWebSocketPool(clobClient *ClobClient, options *WebSocketPoolOptions) *WebSocketPool {
+	if options == nil {
+		options = &WebSocketPoolOptions{}
+	}
+
+	// Set defaults
+	if options.MaxAssetsPerConnection <= 0 {
+		options.MaxAssetsPerConnection = defaultMaxAssetsPerConnection
+	}
+
+	return &WebSocketPool{
+		clobClient:  clobClient,
+		options:     options,
+		callbacks:   &WebSocketCallbacks{},
+		assignments: make(map[string]*poolShard),
+		done:        make(chan struct{}),
+	}
+}
+
+// On registers event handlers. Message handlers receive the merged stream of
+// all connections; OnConnect, OnDisconnect and OnReconnect fire per connection.
+func (p *WebSocketPool) On(callbacks *WebSocketCallbacks) *WebSocketPool {
+	p.dispatchMu.Lock()
+	p.callbacks = callbacks
+	p.dispatchMu.Unlock()
+	return p
+}
+
+// Connect assigns the configured asset IDs and connects every pooled connection
+func (p *WebSocketPool) Connect() error {
+	p.opMu.Lock()
+	defer p.opMu.Unlock()
+
+	p.mu.Lock()
+	var plan []poolOp
+	if len(p.assignments) == 0 && len(p.options.AssetIDs) > 0 {
+		added, err := p.assign(p.options.AssetIDs)
+		if err != nil {
+			p.mu.Unlock()
+			return err
+		}
+		plan = p.subscribeOps(added)
+	}
+
+	// Reopen Wait after a Disconnect
+	select {
+	case <-p.done:
+		p.done = make(chan struct{})
+		p.closeOnce = sync.Once{}
+	default:
+	}
+	p.connected = true
+
+	for _, shard := range p.shards {
+		plan = append(plan, poolOp{shard: shard, action: actionConnect})
+	}
+	p.mu.Unlock()
+
+	return errors.Join(p.run(plan)...)
+}
+
+// Disconnect closes every pooled connection
+func (p *WebSocketPool) Disconnect() {
+	p.opMu.Lock()
+	defer p.opMu.Unlock()
+
+	p.mu.Lock()
+	p.connected = false
+	plan := make([]poolOp, 0, len(p.shards))
+	for _, shard := range p.shards {
+		plan = append(plan, poolOp{shard: shard, action: actionDisconnect})
+	}
+	p.mu.Unlock()
+
+	p.run(plan)
+
+	p.mu.Lock()
+	p.closeOnce.Do(func() { close(p.done) })
+	p.mu.Unlock()
+}
+
+// Wait blocks until the pool is disconnected
+func (p *WebSocketPool) Wait() {
+	p.mu.Lock()
+	done := p.done
+	p.mu.Unlock()
+	<-done
+}
+
+// Subscribe adds asset IDs to the pool, spreading them over the least loaded
+// connections and opening new connections when existing ones are full
+func (p *WebSocketPool) Subscribe(assetIDs []string) error {
+	p.opMu.Lock()
+	defer p.opMu.Unlock()
+
+	p.mu.Lock()
+	added, err := p.assign(assetIDs)
+	if err != nil {
+		p.mu.Unlock()
+		return err
+	}
+	plan := p.subscribeOps(added)
+	p.mu.Unlock()
+
+	return errors.Join(p.run(plan)...)
+}
+
+// Unsubscribe removes asset IDs from the pool and rebalances the remaining
+// assets, closing connections that are no longer needed
+func (p *WebSocketPool) Unsubscribe(assetIDs []string) error {
+	p.opMu.Lock()
+	defer p.opMu.Unlock()
+
+	p.mu.Lock()
+	removed := make(map[*poolShard][]string)
+	var plan []poolOp
+	for _, id := range assetIDs {
+		shard, ok := p.assignments[id]
+		if !ok {
+			continue
+		}
+		delete(p.assignments, id)
+		delete(shard.assets, id)
+		if removed[shard] == nil {
+			plan = append(plan, poolOp{shard: shard, action: actionUnsubscribe})
+		}
+		removed[shard] = append(removed[shard], id)
+	}
+	for i := range plan {
+		plan[i].ids = removed[plan[i].shard]
+	}
+
+	rebalanced, err := p.rebalance()
+	plan = append(plan, rebalanced...)
+	p.mu.Unlock()
+
+	errs := p.run(plan)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// AssetIDs returns all asset IDs currently subscribed through the pool
+func (p *WebSocketPool) AssetIDs() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ids := make([]string, 0, len(p.assignments))
+	for id := range p.assignments {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Health returns a snapshot of the health of every pooled connection
+func (p *WebSocketPool) Health() []ConnectionHealth {
+	p.mu.Lock()
+	shards := append([]*poolShard(nil), p.shards...)
+	counts := make([]int, len(shards))
+	for i, shard := range shards {
+		counts[i] = len(shard.assets)
+	}
+	p.mu.Unlock()
+
+	health := make([]ConnectionHealth, len(shards))
+	for i, shard := range shards {
+		shard.healthMu.Lock()
+		health[i] = shard.health
+		shard.healthMu.Unlock()
+
+		health[i].ID = shard.id
+		health[i].AssetCount = counts[i]
+		health[i].Connected = shard.client.IsConnected()
+		health[i].Stats = shard.client.Stats()
+	}
+
+	return health
+}
+
+// assign places new asset IDs on shards and returns the additions per shard.
+// Callers must hold p.mu.
+func (p *WebSocketPool) assign(assetIDs []string) (map[*poolShard][]string, error) {
+	var fresh []string
+	seen := make(map[string]struct{}, len(assetIDs))
+	for _, id := range assetIDs {
+		if _, ok := p.assignments[id]; ok {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		fresh = append(fresh, id)
+	}
+
+	maxAssets := p.options.MaxAssetsPerConnection
+	if p.options.MaxConnections > 0 {
+		capacity := p.options.MaxConnections*maxAssets - len(p.assignments)
+		if len(fresh) > capacity {
+			return nil, fmt.Errorf("pool capacity exceeded: %d new assets, room for %d", len(fresh), capacity)
+		}
+	}
+
+	added := make(map[*poolShard][]string)
+	for _, id := range fresh {
+		shard := p.leastLoaded()
+		if shard == nil || len(shard.assets) >= maxAssets {
+			shard = p.newShard()
+		}
+		shard.assets[id] = struct{}{}
+		p.assignments[id] = shard
+		added[shard] = append(added[shard], id)
+	}
+
+	return added, nil
+}
+
+// rebalance plans closing surplus connections and evening out the number of
+// assets per connection. Callers must hold p.mu.
+func (p *WebSocketPool) rebalance() ([]poolOp, error) {
+	var plan []poolOp
+	var errs []error
+	maxAssets := p.options.MaxAssetsPerConnection
+	target := (len(p.assignments) + maxAssets - 1) / maxAssets
+
+	// Drain the lightest shards while there are more than needed
+	for len(p.shards) > target {
+		lightest := p.leastLoaded()
+		p.removeShard(lightest)
+
+		if len(lightest.assets) > 0 {
+			ids := make([]string, 0, len(lightest.assets))
+			for id := range lightest.assets {
+				delete(p.assignments, id)
+				ids = append(ids, id)
+			}
+			added, err := p.assign(ids)
+			if err != nil {
+				errs = append(errs, err)
+			}
+			plan = append(plan, p.subscribeOps(added)...)
+		}
+
+		plan = append(plan, poolOp{shard: lightest, action: actionDisconnect})
+	}
+
+	// Move assets from the heaviest to the lightest shard until even
+	for len(p.shards) > 1 {
+		lightest, heaviest := p.leastLoaded(), p.mostLoaded()
+		diff := len(heaviest.assets) - len(lightest.assets)
+		if diff <= 1 {
+			break
+		}
+
+		moved := make([]string, 0, diff/2)
+		for id := range heaviest.assets {
+			if len(moved) == diff/2 {
+				break
+			}
+			moved = append(moved, id)
+		}
+
+		for _, id := range moved {
+			delete(heaviest.assets, id)
+			lightest.assets[id] = struct{}{}
+			p.assignments[id] = lightest
+		}
+
+		// Subscribe on the new connection first so no updates are missed
+		plan = append(plan,
+			poolOp{shard: lightest, action: actionSubscribe, ids: moved},
+			poolOp{shard: heaviest, action: actionUnsubscribe, ids: moved},
+		)
+	}
+
+	return plan, errors.Join(errs...)
+}
+
+// subscribeOps plans subscribing shards to their newly assigned assets and,
+// once the pool is connected, opening shards that have no connection yet.
+// Callers must hold p.mu.
+func (p *WebSocketPool) subscribeOps(added map[*poolShard][]string) []poolOp {
+	var plan []poolOp
+	for shard, ids := range added {
+		plan = append(plan, poolOp{shard: shard, action: actionSubscribe, ids: ids})
+		if p.connected {
+			plan = append(plan, poolOp{shard: shard, action: actionConnect})
+		}
+	}
+	return plan
+}
+
+// run performs the network I/O of a plan. Callers must hold p.opMu but not
+// p.mu. A connection that fails to subscribe is not opened.
+func (p *WebSocketPool) run(plan []poolOp) []error {
+	var errs []error
+	failed := make(map[*poolShard]bool)
+	for _, op := range plan {
+		var err error
+		switch op.action {
+		case actionSubscribe:
+			err = op.shard.client.Subscribe(op.ids)
+		case actionUnsubscribe:
+			err = op.shard.client.Unsubscribe(op.ids)
+		case actionConnect:
+			if failed[op.shard] || op.shard.client.IsConnected() {
+				continue
+			}
+			err = op.shard.client.Connect()
+		case actionDisconnect:
+			op.shard.client.Disconnect()
+		}
+
+		if err != nil {
+			failed[op.shard] = true
+			errs = append(errs, fmt.Errorf("connection %d: %w", op.shard.id, err))
+		}
+	}
+	return errs
+}
+
+func (p *WebSocketPool) leastLoaded() *poolShard {
+	var best *poolShard
+	for _, shard := range p.shards {
+		if best == nil || len(shard.assets) < len(best.assets) {
+			best = shard
+		}
+	}
+	return best
+}
+
+func (p *WebSocketPool) mostLoaded() *poolShard {
+	var best *poolShard
+	for _, shard := range p.shards {
+		if best == nil || len(shard.assets) > len(best.assets) {
+			best = shard
+		}
+	}
+	return best
+}
+
+func (p *WebSocketPool) removeShard(target *poolShard) {
+	for i, shard := range p.shards {
+		if shard == target {
+			p.shards = append(p.shards[:i], p.shards[i+1:]...)
+			return
+		}
+	}
+}
+
+// newShard creates a connection that forwards its events into the pool
+func (p *WebSocketPool) newShard() *poolShard {
+	options := p.options.Connection
+	options.AssetIDs = nil
+
+	shard := &poolShard{
+		id:     p.nextShardID,
+		client: NewWebSocketClient(p.clobClient, &options),
+		assets: make(map[string]struct{}),
+	}
+	p.nextShardID++
+
+	shard.client.On(p.shardCallbacks(shard))
+	p.shards = append(p.shards, shard)
+	return shard
+}
+
+// shardCallbacks builds the callbacks of a single connection, which record
+// health and forward events to the pool callbacks
+func (p *WebSocketPool) shardCallbacks(shard *poolShard) *WebSocketCallbacks {
+	return &WebSocketCallbacks{
+		OnBook: func(msg *types.BookMessage) {
+			p.forward(func(cb *WebSocketCallbacks) {
+				if cb.OnBook != nil {
+					cb.OnBook(msg)
+				}
+			})
+		},
+		OnPriceChange: func(msg *types.PriceChangeMessage) {
+			p.forward(func(cb *WebSocketCallbacks) {
+				if cb.OnPriceChange != nil {
+					cb.OnPriceChange(msg)
+				}
+			})
+		},
+		OnTickSizeChange: func(msg *types.TickSizeChangeMessage) {
+			p.forward(func(cb *WebSocketCallbacks) {
+				if cb.OnTickSizeChange != nil {
+					cb.OnTickSizeChange(msg)
+				}
+			})
+		},
+		OnLastTradePrice: func(msg *types.LastTradePriceMessage) {
+			p.forward(func(cb *WebSocketCallbacks) {
+				if cb.OnLastTradePrice != nil {
+					cb.OnLastTradePrice(msg)
+				}
+			})
+		},
+		OnBestBidAsk: func(msg *types.BestBidAskMessage) {
+			p.forward(func(cb *WebSocketCallbacks) {
+				if cb.OnBestBidAsk != nil {
+					cb.OnBestBidAsk(msg)
+				}
+			})
+		},
+		OnNewMarket: func(msg *types.NewMarketMessage) {
+			p.forward(func(cb *WebSocketCallbacks) {
+				if cb.OnNewMarket != nil {
+					cb.OnNewMarket(msg)
+				}
+			})
+		},
+		OnMarketResolved: func(msg *types.MarketResolvedMessage) {
+			p.forward(func(cb *WebSocketCallbacks) {
+				if cb.OnMarketResolved != nil {
+					cb.OnMarketResolved(msg)
+				}
+			})
+		},
+		OnUnknown: func(eventType types.EventType, raw []byte) {
+			p.forward(func(cb *WebSocketCallbacks) {
+				if cb.OnUnknown != nil {
+					cb.OnUnknown(eventType, raw)
+				}
+			})
+		},
+		OnMessage: func(msg types.MarketChannelMessage) {
+			shard.healthMu.Lock()
+			shard.health.Messages++
+			shard.health.LastMessageAt = time.Now()
+			shard.healthMu.Unlock()
+
+			p.forward(func(cb *WebSocketCallbacks) {
+				if cb.OnMessage != nil {
+					cb.OnMessage(msg)
+				}
+			})
+		},
+		OnError: func(err error) {
+			shard.healthMu.Lock()
+			shard.health.Errors++
+			shard.health.LastError = err
+			shard.healthMu.Unlock()
+
+			p.forward(func(cb *WebSocketCallbacks) {
+				if cb.OnError != nil {
+					cb.OnError(fmt.Errorf("connection %d: %w", shard.id, err))
+				}
+			})
+		},
+		OnConnect: func() {
+			shard.healthMu.Lock()
+			shard.health.ConnectedAt = time.Now()
+			shard.healthMu.Unlock()
+
+			p.forward(func(cb *WebSocketCallbacks) {
+				if cb.OnConnect != nil {
+					cb.OnConnect()
+				}
+			})
+		},
+		OnDisconnect: func(code int, reason string) {
+			p.forward(func(cb *WebSocketCallbacks) {
+				if cb.OnDisconnect != nil {
+					cb.OnDisconnect(code, reason)
+				}
+			})
+		},
+		OnReconnect: func(attempt int) {
+			shard.healthMu.Lock()
+			shard.health.Reconnects++
+			shard.healthMu.Unlock()
+
+			p.forward(func(cb *WebSocketCallbacks) {
+				if cb.OnReconnect != nil {
+					cb.OnReconnect(attempt)
+				}
+			})
+		},
+	}
+}
+
+// forward runs fn against the pool callbacks, one event at a time
+func (p *WebSocketPool) forward(fn func(cb *WebSocketCallbacks)) {
+	p.dispatchMu.Lock()
+	defer p.dispatchMu.Unlock()
+
+	if p.callbacks != nil {
+		fn(p.callbacks)
+	}
+}
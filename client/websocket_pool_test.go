@@ -0,0 +1,229 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// assetIDs returns the asset IDs "1" to "n"
+func assetIDs(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprint(i + 1)
+	}
+	return ids
+}
+
+func newTestPool(t *testing.T, server *wsServer, maxAssets int, ids []string) *WebSocketPool {
+	t.Helper()
+
+	pool := NewWebSocketPool(nil, &WebSocketPoolOptions{
+		AssetIDs:               ids,
+		MaxAssetsPerConnection: maxAssets,
+		Connection:             WebSocketClientOptions{URL: server.URL()},
+	})
+	if err := pool.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Disconnect)
+	return pool
+}
+
+// serverAssets returns the asset IDs held by each open connection, sorted
+// by the number of assets and then by the first asset
+func serverAssets(server *wsServer) []string {
+	var assets []string
+	for _, c := range server.open() {
+		assets = append(assets, strings.Join(c.subscribed(), ","))
+	}
+	sort.Slice(assets, func(i, j int) bool {
+		if len(assets[i]) != len(assets[j]) {
+			return len(assets[i]) < len(assets[j])
+		}
+		return assets[i] < assets[j]
+	})
+	return assets
+}
+
+// waitForAssets waits until the open connections hold exactly want
+func waitForAssets(t *testing.T, server *wsServer, want ...string) {
+	t.Helper()
+
+	eventually(t, fmt.Sprintf("server assets %q", want), func() bool {
+		return fmt.Sprint(serverAssets(server)) == fmt.Sprint(want)
+	})
+}
+
+func TestWebSocketPoolSharding(t *testing.T) {
+	server := newWSServer(t)
+	pool := newTestPool(t, server, 2, assetIDs(5))
+
+	waitForAssets(t, server, "5", "1,2", "3,4")
+
+	health := pool.Health()
+	if len(health) != 3 {
+		t.Fatalf("%d connections, want 3", len(health))
+	}
+	for i, h := range health {
+		if h.ID != i || !h.Connected {
+			t.Errorf("connection %d: ID %d, connected %v", i, h.ID, h.Connected)
+		}
+	}
+	if got := strings.Join(pool.AssetIDs(), ","); got != "1,2,3,4,5" {
+		t.Errorf("AssetIDs = %s", got)
+	}
+
+	// New assets fill the least loaded connection first
+	if err := pool.Subscribe([]string{"6", "5", "7"}); err != nil {
+		t.Fatal(err)
+	}
+	waitForAssets(t, server, "7", "1,2", "3,4", "5,6")
+	if len(server.connections()) != 4 {
+		t.Errorf("%d connections opened, want 4", len(server.connections()))
+	}
+}
+
+func TestWebSocketPoolCapacity(t *testing.T) {
+	pool := NewWebSocketPool(nil, &WebSocketPoolOptions{MaxAssetsPerConnection: 2, MaxConnections: 1})
+
+	if err := pool.Subscribe(assetIDs(3)); err == nil {
+		t.Fatal("Subscribe exceeded the pool capacity")
+	}
+	if len(pool.AssetIDs()) != 0 || len(pool.Health()) != 0 {
+		t.Error("a rejected Subscribe changed the pool")
+	}
+	if err := pool.Subscribe(assetIDs(2)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWebSocketPoolRebalance(t *testing.T) {
+	server := newWSServer(t)
+	pool := newTestPool(t, server, 3, assetIDs(6))
+	waitForAssets(t, server, "1,2,3", "4,5,6")
+
+	// Two connections are still needed, so one of 4, 5 and 6 moves over
+	if err := pool.Unsubscribe([]string{"1", "2"}); err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, "two assets per connection", func() bool {
+		var union []string
+		for _, c := range server.open() {
+			ids := c.subscribed()
+			if len(ids) != 2 {
+				return false
+			}
+			union = append(union, ids...)
+		}
+		sort.Strings(union)
+		return strings.Join(union, ",") == "3,4,5,6"
+	})
+	if len(pool.Health()) != 2 || len(server.connections()) != 2 {
+		t.Errorf("rebalancing changed the number of connections")
+	}
+
+	var counts []int
+	for _, h := range pool.Health() {
+		counts = append(counts, h.AssetCount)
+	}
+	if fmt.Sprint(counts) != "[2 2]" {
+		t.Errorf("asset counts = %v, want [2 2]", counts)
+	}
+}
+
+func TestWebSocketPoolDrain(t *testing.T) {
+	server := newWSServer(t)
+	pool := newTestPool(t, server, 2, assetIDs(5))
+	waitForAssets(t, server, "5", "1,2", "3,4")
+
+	// Two assets fit on one connection, so the others are drained and closed
+	if err := pool.Unsubscribe([]string{"1", "3", "4"}); err != nil {
+		t.Fatal(err)
+	}
+	waitForAssets(t, server, "2,5")
+
+	health := pool.Health()
+	if len(health) != 1 || health[0].AssetCount != 2 {
+		t.Fatalf("health = %+v, want one connection with 2 assets", health)
+	}
+	if got := strings.Join(pool.AssetIDs(), ","); got != "2,5" {
+		t.Errorf("AssetIDs = %s, want 2,5", got)
+	}
+
+	if err := pool.Unsubscribe([]string{"2", "5"}); err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, "all connections closed", func() bool { return len(server.open()) == 0 })
+	if len(pool.Health()) != 0 {
+		t.Errorf("%d connections left", len(pool.Health()))
+	}
+}
+
+func TestWebSocketPoolHealth(t *testing.T) {
+	server := newWSServer(t)
+	pool := newTestPool(t, server, 1, assetIDs(2))
+	waitForAssets(t, server, "1", "2")
+
+	var mu sync.Mutex
+	var trades []string
+	received := make(chan struct{}, 2)
+	pool.On(&WebSocketCallbacks{
+		OnLastTradePrice: func(msg *types.LastTradePriceMessage) {
+			mu.Lock()
+			trades = append(trades, msg.AssetID)
+			mu.Unlock()
+			received <- struct{}{}
+		},
+	})
+
+	for _, c := range server.open() {
+		message := fmt.Sprintf(`{"event_type":"last_trade_price","asset_id":"%s","market":"0x1",`+
+			`"price":"0.5","side":"BUY","size":"10","fee_rate_bps":"0","timestamp":"1700000000000"}`, c.subscribed()[0])
+		if err := c.send(message); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := server.open()[0].send(`{"event_type":"book"}`); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		<-received
+	}
+	eventually(t, "the invalid book error", func() bool {
+		var errs uint64
+		for _, h := range pool.Health() {
+			errs += h.Errors
+		}
+		return errs == 1
+	})
+
+	mu.Lock()
+	sort.Strings(trades)
+	if fmt.Sprint(trades) != "[1 2]" {
+		t.Errorf("trades = %v, want [1 2]", trades)
+	}
+	mu.Unlock()
+
+	for _, h := range pool.Health() {
+		if !h.Connected || h.AssetCount != 1 || h.ConnectedAt.IsZero() {
+			t.Errorf("connection %d: %+v", h.ID, h)
+		}
+		if h.Messages != 1 || h.LastMessageAt.IsZero() {
+			t.Errorf("connection %d: %d messages, last at %v", h.ID, h.Messages, h.LastMessageAt)
+		}
+	}
+
+	pool.Disconnect()
+	pool.Wait()
+	for _, h := range pool.Health() {
+		if h.Connected {
+			t.Errorf("connection %d still connected after Disconnect", h.ID)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package client
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// recorderFlushInterval is how often a Recorder flushes buffered frames
+const recorderFlushInterval = time.Second
+
+// RecordedFrame is a raw WebSocket frame together with the time it was received.
+// Data is stored base64-encoded, so frames replay byte for byte whether or not
+// they are valid JSON.
+type RecordedFrame struct {
+	ReceivedAt time.Time `json:"received_at"`
+	Data       []byte    `json:"data"`
+}
+
+// Recorder writes raw WebSocket frames to a gzip-compressed JSONL file.
+// Frames are flushed every second and on Close; frames received since the
+// last flush are lost if the process crashes.
+type Recorder struct {
+	mu   sync.Mutex
+	file *os.File
+	gz   *gzip.Writer
+	enc  *json.Encoder
+	stop chan struct{}
+}
+
+// NewRecorder creates a recorder that writes to the given file, truncating it
+// if it already exists
+func NewRecorder(path string) (*Recorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recording file: %w", err)
+	}
+
+	gz := gzip.NewWriter(file)
+	enc := json.NewEncoder(gz)
+	enc.SetEscapeHTML(false)
+
+	r := &Recorder{
+		file: file,
+		gz:   gz,
+		enc:  enc,
+		stop: make(chan struct{}),
+	}
+	go r.flushLoop()
+
+	return r, nil
+}
+
+// flushLoop flushes the recording periodically until it is closed. Write
+// errors are sticky, so they are reported by the next Record or Close.
+func (r *Recorder) flushLoop() {
+	ticker := time.NewTicker(recorderFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			r.Flush()
+		}
+	}
+}
+
+// Record appends a single frame to the recording
+func (r *Recorder) Record(receivedAt time.Time, data []byte) error {
+	frame := RecordedFrame{ReceivedAt: receivedAt, Data: data}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.enc == nil {
+		return fmt.Errorf("recorder is closed")
+	}
+
+	if err := r.enc.Encode(frame); err != nil {
+		return fmt.Errorf("failed to write frame: %w", err)
+	}
+
+	return nil
+}
+
+// Flush writes buffered frames to the underlying file
+func (r *Recorder) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.gz == nil {
+		return nil
+	}
+
+	return r.gz.Flush()
+}
+
+// Close flushes the recording and closes the file
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.gz == nil {
+		return nil
+	}
+
+	close(r.stop)
+	gzErr := r.gz.Close()
+	fileErr := r.file.Close()
+	r.gz = nil
+	r.enc = nil
+
+	return errors.Join(gzErr, fileErr)
+}
+
+// ReplayOptions configures a Replayer
+type ReplayOptions struct {
+	// Playback speed relative to the recording: 1 replays at the original
+	// pace, 2 twice as fast, and 0 as fast as possible
+	Speed float64
+}
+
+// Replayer feeds a recording made by Recorder back through a WebSocketClient
+type Replayer struct {
+	options *ReplayOptions
+	file    *os.File
+	gz      *gzip.Reader
+	reader  *bufio.Reader
+}
+
+// NewReplayer opens a recording for replay
+func NewReplayer(path string, options *ReplayOptions) (*Replayer, error) {
+	if options == nil {
+		options = &ReplayOptions{Speed: 1}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recording file: %w", err)
+	}
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+
+	return &Replayer{
+		options: options,
+		file:    file,
+		gz:      gz,
+		reader:  bufio.NewReader(gz),
+	}, nil
+}
+
+// Next returns the next recorded frame, or io.EOF at the end of the recording
+func (r *Replayer) Next() (*RecordedFrame, error) {
+	line, err := r.reader.ReadBytes('\n')
+	if len(line) == 0 && err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("failed to read frame: %w", err)
+	}
+
+	var frame RecordedFrame
+	if err := json.Unmarshal(line, &frame); err != nil {
+		return nil, fmt.Errorf("failed to decode frame: %w", err)
+	}
+
+	return &frame, nil
+}
+
+// Replay dispatches every remaining frame to the client's callbacks, pacing
+// frames according to ReplayOptions.Speed. Frames keep their recorded receive
+// time, so latency statistics match the original session. It returns the
+// number of frames replayed.
+func (r *Replayer) Replay(ctx context.Context, ws *WebSocketClient) (int, error) {
+	var (
+		count    int
+		previous time.Time
+	)
+
+	for {
+		frame, err := r.Next()
+		if err == io.EOF {
+			return count, nil
+		}
+		if err != nil {
+			return count, err
+		}
+
+		if r.options.Speed > 0 && !previous.IsZero() {
+			delay := time.Duration(float64(frame.ReceivedAt.Sub(previous)) / r.options.Speed)
+			if delay > 0 {
+				timer := time.NewTimer(delay)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return count, ctx.Err()
+				case <-timer.C:
+				}
+			}
+		}
+
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+
+		previous = frame.ReceivedAt
+		ws.recordFrame(len(frame.Data))
+		ws.processMessage(frame.Data, frame.ReceivedAt)
+		count++
+	}
+}
+
+// Close closes the recording file
+func (r *Replayer) Close() error {
+	return errors.Join(r.gz.Close(), r.file.Close())
+}
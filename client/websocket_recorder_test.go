@@ -0,0 +1,202 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// callbackLog records the callbacks fired by a client and the latency of
+// each message, which depends on the time it was received
+type callbackLog struct {
+	mu        sync.Mutex
+	events    []string
+	latencies []time.Duration
+}
+
+func (l *callbackLog) add(event string) {
+	l.mu.Lock()
+	l.events = append(l.events, event)
+	l.mu.Unlock()
+}
+
+func (l *callbackLog) snapshot() ([]string, []time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.events...), append([]time.Duration(nil), l.latencies...)
+}
+
+func (l *callbackLog) attach(ws *WebSocketClient) {
+	ws.On(&WebSocketCallbacks{
+		OnLastTradePrice: func(msg *types.LastTradePriceMessage) {
+			l.add(fmt.Sprintf("trade %s %s %s", msg.AssetID, msg.Price, msg.Size))
+		},
+		OnBestBidAsk: func(msg *types.BestBidAskMessage) {
+			l.add(fmt.Sprintf("best %s %s/%s", msg.AssetID, msg.BestBid, msg.BestAsk))
+		},
+		OnTickSizeChange: func(msg *types.TickSizeChangeMessage) {
+			l.add(fmt.Sprintf("tick %s %s", msg.AssetID, msg.NewTickSize))
+		},
+		OnUnknown: func(eventType types.EventType, data []byte) {
+			l.add(fmt.Sprintf("unknown %s", eventType))
+		},
+		OnError: func(err error) {
+			l.add("error")
+		},
+	})
+	ws.options.Metrics = &WebSocketMetricsHooks{
+		OnMessage: func(eventType types.EventType, latency time.Duration) {
+			l.mu.Lock()
+			l.latencies = append(l.latencies, latency)
+			l.mu.Unlock()
+		},
+	}
+}
+
+func TestRecordReplay(t *testing.T) {
+	// Frames keep their whitespace and number formatting in the recording
+	frames := []string{
+		`{ "event_type": "last_trade_price", "asset_id": "1", "market": "0x1", "price": 0.50,` +
+			` "side": "BUY", "size": "10", "fee_rate_bps": "0", "timestamp": "1700000000000" }`,
+		`[{"event_type":"best_bid_ask","asset_id":"1","market":"0x1","best_bid":"0.49","best_ask":"0.51",` +
+			`"spread":"0.02","timestamp":"1700000000100"},` +
+			`{"event_type":"tick_size_change","asset_id":"1","market":"0x1","old_tick_size":"0.01",` +
+			`"new_tick_size":"0.001","timestamp":"1700000000200"}]`,
+		`{"event_type":"future_event","timestamp":"1700000000300"}`,
+		`not json`,
+	}
+	want := []string{"trade 1 0.50 10", "best 1 0.49/0.51", "tick 1 0.001", "unknown future_event", "error"}
+
+	path := filepath.Join(t.TempDir(), "session.jsonl.gz")
+	recorder, err := NewRecorder(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := newWSServer(t)
+	live := NewWebSocketClient(nil, &WebSocketClientOptions{URL: server.URL(), AssetIDs: []string{"1"}, Recorder: recorder})
+	var liveLog callbackLog
+	liveLog.attach(live)
+	if err := live.Connect(); err != nil {
+		t.Fatal(err)
+	}
+
+	eventually(t, "the connection", func() bool { return len(server.connections()) == 1 })
+	for _, frame := range frames {
+		if err := server.connections()[0].send(frame); err != nil {
+			t.Fatal(err)
+		}
+	}
+	eventually(t, "the live callbacks", func() bool {
+		events, _ := liveLog.snapshot()
+		return len(events) == len(want)
+	})
+	live.Disconnect()
+	live.Wait()
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	liveEvents, liveLatencies := liveLog.snapshot()
+	if fmt.Sprint(liveEvents) != fmt.Sprint(want) {
+		t.Fatalf("live callbacks = %q, want %q", liveEvents, want)
+	}
+
+	// The recording holds every frame byte for byte
+	replayer, err := NewReplayer(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The last frame is not a message, so it is not counted by LastMessageAt
+	var lastMessageAt time.Time
+	for i, frame := range frames {
+		recorded, err := replayer.Next()
+		if err != nil {
+			t.Fatalf("frame %d: %v", i, err)
+		}
+		if string(recorded.Data) != frame {
+			t.Errorf("frame %d = %s, want %s", i, recorded.Data, frame)
+		}
+		if i < len(frames)-1 {
+			lastMessageAt = recorded.ReceivedAt
+		}
+	}
+	if _, err := replayer.Next(); err != io.EOF {
+		t.Errorf("Next at the end = %v, want io.EOF", err)
+	}
+	replayer.Close()
+
+	// Replaying fires the same callbacks with the original receive times
+	replayer, err = NewReplayer(path, &ReplayOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer replayer.Close()
+
+	replay := NewWebSocketClient(nil, &WebSocketClientOptions{})
+	var replayLog callbackLog
+	replayLog.attach(replay)
+	n, err := replayer.Replay(context.Background(), replay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(frames) {
+		t.Errorf("replayed %d frames, want %d", n, len(frames))
+	}
+
+	replayEvents, replayLatencies := replayLog.snapshot()
+	if fmt.Sprint(replayEvents) != fmt.Sprint(liveEvents) {
+		t.Errorf("replayed callbacks = %q, want %q", replayEvents, liveEvents)
+	}
+	if fmt.Sprint(replayLatencies) != fmt.Sprint(liveLatencies) {
+		t.Errorf("replayed latencies = %v, want %v", replayLatencies, liveLatencies)
+	}
+
+	liveStats, replayStats := live.Stats(), replay.Stats()
+	if !replayStats.LastMessageAt.Equal(liveStats.LastMessageAt) || !replayStats.LastMessageAt.Equal(lastMessageAt) {
+		t.Errorf("last message at %v, want %v", replayStats.LastMessageAt, liveStats.LastMessageAt)
+	}
+	if replayStats.ParseErrors != 1 || fmt.Sprint(replayStats.MessagesByType) != fmt.Sprint(liveStats.MessagesByType) {
+		t.Errorf("replayed stats = %+v, want %+v", replayStats, liveStats)
+	}
+}
+
+func TestReplayCanceled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.jsonl.gz")
+	recorder, err := NewRecorder(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	for i := 0; i < 2; i++ {
+		if err := recorder.Record(start.Add(time.Duration(i)*time.Hour), []byte(`{"event_type":"future_event"}`)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.Record(start, []byte("{}")); err == nil {
+		t.Error("Record succeeded after Close")
+	}
+
+	replayer, err := NewReplayer(path, &ReplayOptions{Speed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer replayer.Close()
+
+	// The second frame is an hour after the first, so the replay is canceled while waiting
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	n, err := replayer.Replay(ctx, NewWebSocketClient(nil, &WebSocketClientOptions{}))
+	if err != context.DeadlineExceeded || n != 1 {
+		t.Errorf("Replay = %d, %v, want 1 frame and the deadline", n, err)
+	}
+}
@@ -0,0 +1,212 @@
+package client
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// WebSocketStats is a snapshot of the statistics of a WebSocket connection
+type WebSocketStats struct {
+	// Parsed messages per event type
+	MessagesByType map[types.EventType]uint64
+
+	// Raw frames and bytes read from the connection, including PONGs
+	FramesReceived uint64
+	BytesReceived  uint64
+
+	ParseErrors uint64
+	Reconnects  uint64
+
+	// Round-trip time of the most recent PING/PONG exchange
+	PingRTT time.Duration
+
+	// Delay between the server timestamp of a message and its receipt
+	LastLatency time.Duration
+	AvgLatency  time.Duration
+	MaxLatency  time.Duration
+
+	LastMessageAt time.Time
+}
+
+// WebSocketMetricsHooks receives measurements as they are taken, for metrics exporters
+type WebSocketMetricsHooks struct {
+	OnFrame      func(bytes int)
+	OnMessage    func(eventType types.EventType, latency time.Duration)
+	OnParseError func(err error)
+	OnReconnect  func(attempt int)
+	OnPingRTT    func(rtt time.Duration)
+}
+
+// wsStats accumulates the statistics of a WebSocketClient
+type wsStats struct {
+	mu sync.Mutex
+
+	messagesByType map[types.EventType]uint64
+	frames         uint64
+	bytes          uint64
+	parseErrors    uint64
+	reconnects     uint64
+	pingSentAt     time.Time
+	pingRTT        time.Duration
+	lastLatency    time.Duration
+	maxLatency     time.Duration
+	latencySum     time.Duration
+	latencyCount   uint64
+	lastMessageAt  time.Time
+}
+
+func newWSStats() *wsStats {
+	return &wsStats{messagesByType: make(map[types.EventType]uint64)}
+}
+
+// Stats returns a snapshot of the connection statistics
+func (ws *WebSocketClient) Stats() WebSocketStats {
+	s := ws.stats
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	byType := make(map[types.EventType]uint64, len(s.messagesByType))
+	for eventType, count := range s.messagesByType {
+		byType[eventType] = count
+	}
+
+	var avgLatency time.Duration
+	if s.latencyCount > 0 {
+		avgLatency = s.latencySum / time.Duration(s.latencyCount)
+	}
+
+	return WebSocketStats{
+		MessagesByType: byType,
+		FramesReceived: s.frames,
+		BytesReceived:  s.bytes,
+		ParseErrors:    s.parseErrors,
+		Reconnects:     s.reconnects,
+		PingRTT:        s.pingRTT,
+		LastLatency:    s.lastLatency,
+		AvgLatency:     avgLatency,
+		MaxLatency:     s.maxLatency,
+		LastMessageAt:  s.lastMessageAt,
+	}
+}
+
+// ResetStats clears the connection statistics
+func (ws *WebSocketClient) ResetStats() {
+	s := ws.stats
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// An outstanding PING is kept so its PONG is still measured
+	s.messagesByType = make(map[types.EventType]uint64)
+	s.frames = 0
+	s.bytes = 0
+	s.parseErrors = 0
+	s.reconnects = 0
+	s.pingRTT = 0
+	s.lastLatency = 0
+	s.maxLatency = 0
+	s.latencySum = 0
+	s.latencyCount = 0
+	s.lastMessageAt = time.Time{}
+}
+
+func (ws *WebSocketClient) recordFrame(size int) {
+	ws.stats.mu.Lock()
+	ws.stats.frames++
+	ws.stats.bytes += uint64(size)
+	ws.stats.mu.Unlock()
+
+	if ws.options.Metrics != nil && ws.options.Metrics.OnFrame != nil {
+		ws.options.Metrics.OnFrame(size)
+	}
+}
+
+func (ws *WebSocketClient) recordMessage(eventType types.EventType, timestamp string, receivedAt time.Time) {
+	// Latency is only known when the message carries a server timestamp
+	var latency time.Duration
+	if ms, err := strconv.ParseInt(timestamp, 10, 64); err == nil && ms > 0 {
+		latency = receivedAt.Sub(time.UnixMilli(ms))
+	}
+
+	ws.stats.mu.Lock()
+	ws.stats.messagesByType[eventType]++
+	ws.stats.lastMessageAt = receivedAt
+	if latency != 0 {
+		ws.stats.lastLatency = latency
+		ws.stats.latencySum += latency
+		ws.stats.latencyCount++
+		if latency > ws.stats.maxLatency {
+			ws.stats.maxLatency = latency
+		}
+	}
+	ws.stats.mu.Unlock()
+
+	if ws.options.Metrics != nil && ws.options.Metrics.OnMessage != nil {
+		ws.options.Metrics.OnMessage(eventType, latency)
+	}
+}
+
+func (ws *WebSocketClient) recordParseError(err error) {
+	ws.stats.mu.Lock()
+	ws.stats.parseErrors++
+	ws.stats.mu.Unlock()
+
+	if ws.options.Metrics != nil && ws.options.Metrics.OnParseError != nil {
+		ws.options.Metrics.OnParseError(err)
+	}
+}
+
+func (ws *WebSocketClient) recordReconnect(attempt int) {
+	ws.stats.mu.Lock()
+	ws.stats.reconnects++
+	ws.stats.mu.Unlock()
+
+	if ws.options.Metrics != nil && ws.options.Metrics.OnReconnect != nil {
+		ws.options.Metrics.OnReconnect(attempt)
+	}
+}
+
+func (ws *WebSocketClient) recordPingSent(sentAt time.Time) {
+	ws.stats.mu.Lock()
+	ws.stats.pingSentAt = sentAt
+	ws.stats.mu.Unlock()
+}
+
+func (ws *WebSocketClient) recordPong(receivedAt time.Time) {
+	ws.stats.mu.Lock()
+	if ws.stats.pingSentAt.IsZero() {
+		ws.stats.mu.Unlock()
+		return
+	}
+	rtt := receivedAt.Sub(ws.stats.pingSentAt)
+	ws.stats.pingRTT = rtt
+	ws.stats.pingSentAt = time.Time{}
+	ws.stats.mu.Unlock()
+
+	if ws.options.Metrics != nil && ws.options.Metrics.OnPingRTT != nil {
+		ws.options.Metrics.OnPingRTT(rtt)
+	}
+}
+
+// messageTimestamp returns the server timestamp carried by a message
+func messageTimestamp(msg types.MarketChannelMessage) string {
+	switch m := msg.(type) {
+	case *types.BookMessage:
+		return m.Timestamp
+	case *types.PriceChangeMessage:
+		return m.Timestamp
+	case *types.TickSizeChangeMessage:
+		return m.Timestamp
+	case *types.LastTradePriceMessage:
+		return m.Timestamp
+	case *types.BestBidAskMessage:
+		return m.Timestamp
+	case *types.NewMarketMessage:
+		return m.Timestamp
+	case *types.MarketResolvedMessage:
+		return m.Timestamp
+	}
+	return ""
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// metricsLog collects what the metrics hooks receive
+type metricsLog struct {
+	frames      []int
+	latencies   map[types.EventType][]time.Duration
+	parseErrors int
+	reconnects  []int
+	rtts        []time.Duration
+}
+
+func (l *metricsLog) hooks() *WebSocketMetricsHooks {
+	l.latencies = make(map[types.EventType][]time.Duration)
+	return &WebSocketMetricsHooks{
+		OnFrame: func(bytes int) { l.frames = append(l.frames, bytes) },
+		OnMessage: func(eventType types.EventType, latency time.Duration) {
+			l.latencies[eventType] = append(l.latencies[eventType], latency)
+		},
+		OnParseError: func(err error) { l.parseErrors++ },
+		OnReconnect:  func(attempt int) { l.reconnects = append(l.reconnects, attempt) },
+		OnPingRTT:    func(rtt time.Duration) { l.rtts = append(l.rtts, rtt) },
+	}
+}
+
+func TestWebSocketStatsCounters(t *testing.T) {
+	metrics := &metricsLog{}
+	ws := NewWebSocketClient(nil, &WebSocketClientOptions{Metrics: metrics.hooks()})
+	ws.On(&WebSocketCallbacks{OnError: func(err error) {}})
+
+	frames := []string{
+		`{"event_type":"last_trade_price","asset_id":"1","market":"0x1","price":"0.5","side":"BUY",` +
+			`"size":"10","fee_rate_bps":"0","timestamp":"1700000000000"}`,
+		`[{"event_type":"best_bid_ask","asset_id":"1","market":"0x1","best_bid":"0.49","best_ask":"0.51",` +
+			`"spread":"0.02","timestamp":"1700000000100"},` +
+			`{"event_type":"future_event","timestamp":"1700000000300"}]`,
+		`{"event_type":"future_event"}`,
+		`{"event_type":"book"}`,
+	}
+	receivedAt := time.UnixMilli(1700000000500)
+	var bytes uint64
+	for _, frame := range frames {
+		ws.recordFrame(len(frame))
+		ws.processMessage([]byte(frame), receivedAt)
+		bytes += uint64(len(frame))
+	}
+	ws.recordReconnect(1)
+	ws.recordReconnect(2)
+
+	stats := ws.Stats()
+	wantByType := map[types.EventType]uint64{
+		types.EventTypeLastTradePrice: 1,
+		types.EventTypeBestBidAsk:     1,
+		"future_event":                2,
+	}
+	if !reflect.DeepEqual(stats.MessagesByType, wantByType) {
+		t.Errorf("messages by type = %v, want %v", stats.MessagesByType, wantByType)
+	}
+	if stats.FramesReceived != uint64(len(frames)) || stats.BytesReceived != bytes {
+		t.Errorf("frames = %d, bytes = %d, want %d, %d", stats.FramesReceived, stats.BytesReceived, len(frames), bytes)
+	}
+	if stats.ParseErrors != 1 || stats.Reconnects != 2 {
+		t.Errorf("parse errors = %d, reconnects = %d, want 1, 2", stats.ParseErrors, stats.Reconnects)
+	}
+
+	// Messages without a server timestamp are counted but have no latency
+	ms := time.Millisecond
+	if stats.LastLatency != 200*ms || stats.MaxLatency != 500*ms || stats.AvgLatency != (500+400+200)*ms/3 {
+		t.Errorf("latency last = %v, max = %v, avg = %v, want 200ms, 500ms, %v",
+			stats.LastLatency, stats.MaxLatency, stats.AvgLatency, (500+400+200)*ms/3)
+	}
+	if !stats.LastMessageAt.Equal(receivedAt) {
+		t.Errorf("last message at = %v, want %v", stats.LastMessageAt, receivedAt)
+	}
+
+	if len(metrics.frames) != len(frames) || metrics.parseErrors != 1 || !reflect.DeepEqual(metrics.reconnects, []int{1, 2}) {
+		t.Errorf("hooks got frames %v, %d parse errors, reconnects %v", metrics.frames, metrics.parseErrors, metrics.reconnects)
+	}
+	wantLatencies := map[types.EventType][]time.Duration{
+		types.EventTypeLastTradePrice: {500 * ms},
+		types.EventTypeBestBidAsk:     {400 * ms},
+		"future_event":                {200 * ms, 0},
+	}
+	if !reflect.DeepEqual(metrics.latencies, wantLatencies) {
+		t.Errorf("hook latencies = %v, want %v", metrics.latencies, wantLatencies)
+	}
+
+	// The snapshot is a copy
+	stats.MessagesByType[types.EventTypeBook] = 7
+	if _, ok := ws.Stats().MessagesByType[types.EventTypeBook]; ok {
+		t.Error("changing a snapshot changed the stats")
+	}
+
+	ws.ResetStats()
+	if got := ws.Stats(); !reflect.DeepEqual(got, WebSocketStats{MessagesByType: map[types.EventType]uint64{}}) {
+		t.Errorf("stats after reset = %+v", got)
+	}
+}
+
+func TestWebSocketStatsPingRTT(t *testing.T) {
+	metrics := &metricsLog{}
+	ws := NewWebSocketClient(nil, &WebSocketClientOptions{Metrics: metrics.hooks()})
+	start := time.Now()
+
+	// A PONG without an outstanding PING is not measured
+	ws.recordPong(start)
+	if rtt := ws.Stats().PingRTT; rtt != 0 {
+		t.Errorf("RTT without a PING = %v, want 0", rtt)
+	}
+
+	ws.recordPingSent(start)
+	ws.recordPong(start.Add(40 * time.Millisecond))
+	// A duplicate PONG does not count again
+	ws.recordPong(start.Add(90 * time.Millisecond))
+	if rtt := ws.Stats().PingRTT; rtt != 40*time.Millisecond {
+		t.Errorf("RTT = %v, want 40ms", rtt)
+	}
+
+	// Resetting keeps the outstanding PING, so its PONG is still measured
+	ws.recordPingSent(start.Add(time.Second))
+	ws.ResetStats()
+	ws.recordPong(start.Add(time.Second + 15*time.Millisecond))
+	if rtt := ws.Stats().PingRTT; rtt != 15*time.Millisecond {
+		t.Errorf("RTT after reset = %v, want 15ms", rtt)
+	}
+
+	if want := []time.Duration{40 * time.Millisecond, 15 * time.Millisecond}; !reflect.DeepEqual(metrics.rtts, want) {
+		t.Errorf("OnPingRTT got %v, want %v", metrics.rtts, want)
+	}
+}
@@ -0,0 +1,80 @@
+package ctf
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// ConditionalTokensABI is the subset of the Gnosis ConditionalTokens ABI used by the SDK
+const ConditionalTokensABI = `[
+	{"type":"function","name":"splitPosition","stateMutability":"nonpayable","inputs":[{"name":"collateralToken","type":"address"},{"name":"parentCollectionId","type":"bytes32"},{"name":"conditionId","type":"bytes32"},{"name":"partition","type":"uint256[]"},{"name":"amount","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"mergePositions","stateMutability":"nonpayable","inputs":[{"name":"collateralToken","type":"address"},{"name":"parentCollectionId","type":"bytes32"},{"name":"conditionId","type":"bytes32"},{"name":"partition","type":"uint256[]"},{"name":"amount","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"redeemPositions","stateMutability":"nonpayable","inputs":[{"name":"collateralToken","type":"address"},{"name":"parentCollectionId","type":"bytes32"},{"name":"conditionId","type":"bytes32"},{"name":"indexSets","type":"uint256[]"}],"outputs":[]},
+	{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"owner","type":"address"},{"name":"id","type":"uint256"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"isApprovedForAll","stateMutability":"view","inputs":[{"name":"owner","type":"address"},{"name":"operator","type":"address"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"setApprovalForAll","stateMutability":"nonpayable","inputs":[{"name":"operator","type":"address"},{"name":"approved","type":"bool"}],"outputs":[]},
+	{"type":"function","name":"getOutcomeSlotCount","stateMutability":"view","inputs":[{"name":"conditionId","type":"bytes32"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"payoutNumerators","stateMutability":"view","inputs":[{"name":"","type":"bytes32"},{"name":"","type":"uint256"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"payoutDenominator","stateMutability":"view","inputs":[{"name":"","type":"bytes32"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"event","name":"PositionSplit","anonymous":false,"inputs":[{"name":"stakeholder","type":"address","indexed":true},{"name":"collateralToken","type":"address","indexed":false},{"name":"parentCollectionId","type":"bytes32","indexed":true},{"name":"conditionId","type":"bytes32","indexed":true},{"name":"partition","type":"uint256[]","indexed":false},{"name":"amount","type":"uint256","indexed":false}]},
+	{"type":"event","name":"PositionsMerge","anonymous":false,"inputs":[{"name":"stakeholder","type":"address","indexed":true},{"name":"collateralToken","type":"address","indexed":false},{"name":"parentCollectionId","type":"bytes32","indexed":true},{"name":"conditionId","type":"bytes32","indexed":true},{"name":"partition","type":"uint256[]","indexed":false},{"name":"amount","type":"uint256","indexed":false}]},
+	{"type":"event","name":"PayoutRedemption","anonymous":false,"inputs":[{"name":"redeemer","type":"address","indexed":true},{"name":"collateralToken","type":"address","indexed":true},{"name":"parentCollectionId","type":"bytes32","indexed":true},{"name":"conditionId","type":"bytes32","indexed":false},{"name":"indexSets","type":"uint256[]","indexed":false},{"name":"payout","type":"uint256","indexed":false}]}
+]`
+
+// CTFExchangeABI is the subset of the Polymarket CTF Exchange ABI used by the
+// SDK. The neg risk exchange shares it.
+const CTFExchangeABI = `[
+	{"type":"function","name":"cancelOrder","stateMutability":"nonpayable","inputs":[{"name":"order","type":"tuple","components":[{"name":"salt","type":"uint256"},{"name":"maker","type":"address"},{"name":"signer","type":"address"},{"name":"taker","type":"address"},{"name":"tokenId","type":"uint256"},{"name":"makerAmount","type":"uint256"},{"name":"takerAmount","type":"uint256"},{"name":"expiration","type":"uint256"},{"name":"nonce","type":"uint256"},{"name":"feeRateBps","type":"uint256"},{"name":"side","type":"uint8"},{"name":"signatureType","type":"uint8"},{"name":"signature","type":"bytes"}]}],"outputs":[]},
+	{"type":"function","name":"cancelOrders","stateMutability":"nonpayable","inputs":[{"name":"orders","type":"tuple[]","components":[{"name":"salt","type":"uint256"},{"name":"maker","type":"address"},{"name":"signer","type":"address"},{"name":"taker","type":"address"},{"name":"tokenId","type":"uint256"},{"name":"makerAmount","type":"uint256"},{"name":"takerAmount","type":"uint256"},{"name":"expiration","type":"uint256"},{"name":"nonce","type":"uint256"},{"name":"feeRateBps","type":"uint256"},{"name":"side","type":"uint8"},{"name":"signatureType","type":"uint8"},{"name":"signature","type":"bytes"}]}],"outputs":[]},
+	{"type":"function","name":"incrementNonce","stateMutability":"nonpayable","inputs":[],"outputs":[]},
+	{"type":"function","name":"nonces","stateMutability":"view","inputs":[{"name":"","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"event","name":"OrderFilled","anonymous":false,"inputs":[{"name":"orderHash","type":"bytes32","indexed":true},{"name":"maker","type":"address","indexed":true},{"name":"taker","type":"address","indexed":true},{"name":"makerAssetId","type":"uint256","indexed":false},{"name":"takerAssetId","type":"uint256","indexed":false},{"name":"makerAmountFilled","type":"uint256","indexed":false},{"name":"takerAmountFilled","type":"uint256","indexed":false},{"name":"fee","type":"uint256","indexed":false}]},
+	{"type":"event","name":"OrdersMatched","anonymous":false,"inputs":[{"name":"takerOrderHash","type":"bytes32","indexed":true},{"name":"takerOrderMaker","type":"address","indexed":true},{"name":"makerAssetId","type":"uint256","indexed":false},{"name":"takerAssetId","type":"uint256","indexed":false},{"name":"makerAmountFilled","type":"uint256","indexed":false},{"name":"takerAmountFilled","type":"uint256","indexed":false}]},
+	{"type":"event","name":"OrderCancelled","anonymous":false,"inputs":[{"name":"orderHash","type":"bytes32","indexed":true}]}
+]`
+
+// NegRiskAdapterABI is the subset of the Polymarket NegRiskAdapter ABI used by the SDK
+const NegRiskAdapterABI = `[
+	{"type":"function","name":"splitPosition","stateMutability":"nonpayable","inputs":[{"name":"_conditionId","type":"bytes32"},{"name":"_amount","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"mergePositions","stateMutability":"nonpayable","inputs":[{"name":"_conditionId","type":"bytes32"},{"name":"_amount","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"redeemPositions","stateMutability":"nonpayable","inputs":[{"name":"_conditionId","type":"bytes32"},{"name":"_amounts","type":"uint256[]"}],"outputs":[]}
+]`
+
+// UmaCtfAdapterABI is the subset of the UMA CTF Adapter ABI used by the SDK.
+// The neg risk UMA adapter shares it.
+const UmaCtfAdapterABI = `[
+	{"type":"function","name":"getQuestion","stateMutability":"view","inputs":[{"name":"questionID","type":"bytes32"}],"outputs":[{"name":"","type":"tuple","components":[{"name":"requestTimestamp","type":"uint256"},{"name":"reward","type":"uint256"},{"name":"proposalBond","type":"uint256"},{"name":"liveness","type":"uint256"},{"name":"manualResolutionTimestamp","type":"uint256"},{"name":"resolved","type":"bool"},{"name":"paused","type":"bool"},{"name":"reset","type":"bool"},{"name":"refund","type":"bool"},{"name":"rewardToken","type":"address"},{"name":"creator","type":"address"},{"name":"ancillaryData","type":"bytes"}]}]},
+	{"type":"function","name":"optimisticOracle","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"address"}]}
+]`
+
+// OptimisticOracleV2ABI is the subset of the UMA Optimistic Oracle V2 ABI used by the SDK
+const OptimisticOracleV2ABI = `[
+	{"type":"function","name":"getState","stateMutability":"view","inputs":[{"name":"requester","type":"address"},{"name":"identifier","type":"bytes32"},{"name":"timestamp","type":"uint256"},{"name":"ancillaryData","type":"bytes"}],"outputs":[{"name":"","type":"uint8"}]}
+]`
+
+// ERC20ABI is the subset of the ERC20 ABI used for the collateral token
+const ERC20ABI = `[
+	{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"account","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"allowance","stateMutability":"view","inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"approve","stateMutability":"nonpayable","inputs":[{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]}
+]`
+
+var (
+	erc20ABI             = MustParseABI(ERC20ABI)
+	conditionalTokensABI = MustParseABI(ConditionalTokensABI)
+	negRiskAdapterABI    = MustParseABI(NegRiskAdapterABI)
+	exchangeABI          = MustParseABI(CTFExchangeABI)
+	umaCtfAdapterABI     = MustParseABI(UmaCtfAdapterABI)
+	optimisticOracleABI  = MustParseABI(OptimisticOracleV2ABI)
+)
+
+// MustParseABI parses an ABI definition such as ConditionalTokensABI and
+// panics if it is invalid, for package-level variables
+func MustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
@@ -0,0 +1,175 @@
+package ctf
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// SpenderApproval is the approval state of one contract that moves the
+// owner's collateral and outcome tokens
+type SpenderApproval struct {
+	Name    string
+	Spender common.Address
+	// Allowance is the collateral allowance in base units
+	Allowance *big.Int
+	// ApprovedForAll reports the ConditionalTokens operator approval
+	ApprovedForAll bool
+	// CollateralApproved reports whether the allowance covers the balance
+	CollateralApproved bool
+}
+
+// Ready reports whether the spender can move both collateral and outcome tokens
+func (s SpenderApproval) Ready() bool {
+	return s.CollateralApproved && s.ApprovedForAll
+}
+
+// Approvals is the on-chain trading readiness of a wallet. It complements
+// ClobClient.GetBalanceAllowance, which reports what the CLOB has indexed.
+type Approvals struct {
+	Owner common.Address
+	// Balance is the collateral balance in base units
+	Balance  *big.Int
+	Spenders []SpenderApproval
+}
+
+// Ready reports whether every spender is approved
+func (a *Approvals) Ready() bool {
+	for _, spender := range a.Spenders {
+		if !spender.Ready() {
+			return false
+		}
+	}
+	return true
+}
+
+// Allowances returns the collateral allowances keyed by spender address, as
+// in the allowances of the CLOB balance-allowance response
+func (a *Approvals) Allowances() map[string]string {
+	allowances := make(map[string]string, len(a.Spenders))
+	for _, spender := range a.Spenders {
+		allowances[spender.Spender.Hex()] = spender.Allowance.String()
+	}
+	return allowances
+}
+
+type spender struct {
+	name    string
+	address common.Address
+}
+
+// spenders returns the contracts that need approvals before trading
+func (c *Client) spenders() []spender {
+	return []spender{
+		{"CTF Exchange", c.contracts.Exchange},
+		{"Neg Risk CTF Exchange", c.contracts.NegRiskExchange},
+		{"Neg Risk Adapter", c.contracts.NegRiskAdapter},
+	}
+}
+
+// CheckApprovals reads the collateral balance, allowances and operator
+// approvals of an owner, e.g. the funder address of a ClobClient
+func (c *Client) CheckApprovals(ctx context.Context, owner common.Address) (*Approvals, error) {
+	balance, err := callUint(ctx, c.collateralToken, "balanceOf", owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collateral balance: %w", err)
+	}
+
+	approvals := &Approvals{Owner: owner, Balance: balance}
+	for _, spender := range c.spenders() {
+		if spender.address == (common.Address{}) {
+			return nil, fmt.Errorf("%s address is not configured", spender.name)
+		}
+
+		allowance, err := callUint(ctx, c.collateralToken, "allowance", owner, spender.address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s allowance: %w", spender.name, err)
+		}
+
+		var out []any
+		if err := c.conditionalTokens.Call(&bind.CallOpts{Context: ctx}, &out, "isApprovedForAll", owner, spender.address); err != nil {
+			return nil, fmt.Errorf("failed to get %s approval: %w", spender.name, err)
+		}
+		approved, _ := out[0].(bool)
+
+		approvals.Spenders = append(approvals.Spenders, SpenderApproval{
+			Name:               spender.name,
+			Spender:            spender.address,
+			Allowance:          allowance,
+			ApprovedForAll:     approved,
+			CollateralApproved: allowance.Sign() > 0 && allowance.Cmp(balance) >= 0,
+		})
+	}
+
+	return approvals, nil
+}
+
+// SetupApprovals sends the missing approvals of the signer: an unlimited
+// collateral allowance and a ConditionalTokens operator approval for each
+// spender. Use NoSend to only build and sign them. Wallets whose funds are
+// held by a proxy wallet or Safe must approve from that contract instead.
+func (c *Client) SetupApprovals(opts *TxOptions) ([]*ethtypes.Transaction, error) {
+	if c.signer == nil {
+		return nil, fmt.Errorf("signer is required to send transactions")
+	}
+
+	ctx := context.Background()
+	if opts != nil && opts.Context != nil {
+		ctx = opts.Context
+	}
+
+	approvals, err := c.CheckApprovals(ctx, c.signer.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	// Nonces are assigned here so unsent transactions do not collide
+	txOpts := TxOptions{}
+	if opts != nil {
+		txOpts = *opts
+	}
+	var nonce uint64
+	if txOpts.Nonce != nil {
+		nonce = *txOpts.Nonce
+	} else {
+		nonce, err = c.backend.PendingNonceAt(ctx, c.signer.GetAddress())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get nonce: %w", err)
+		}
+	}
+
+	var txs []*ethtypes.Transaction
+	send := func(contract *bind.BoundContract, method string, params ...any) error {
+		n := nonce
+		txOpts.Nonce = &n
+
+		tx, err := c.transact(contract, &txOpts, method, params...)
+		if err != nil {
+			return err
+		}
+
+		txs = append(txs, tx)
+		nonce++
+		return nil
+	}
+
+	for _, spender := range approvals.Spenders {
+		if !spender.CollateralApproved {
+			if err := send(c.collateralToken, "approve", spender.Spender, math.MaxBig256); err != nil {
+				return txs, err
+			}
+		}
+		if !spender.ApprovedForAll {
+			if err := send(c.conditionalTokens, "setApprovalForAll", spender.Spender, true); err != nil {
+				return txs, err
+			}
+		}
+	}
+
+	return txs, nil
+}
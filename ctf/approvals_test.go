@@ -0,0 +1,202 @@
+package ctf
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// approvalState is the on-chain state read by CheckApprovals
+type approvalState struct {
+	balance    *big.Int
+	allowances map[common.Address]*big.Int
+	operators  map[common.Address]bool
+}
+
+// deployApprovals registers the collateral token and ConditionalTokens of state
+func deployApprovals(backend *testBackend, state approvalState) {
+	backend.deploy(testContracts.Collateral, erc20ABI, func(method string, args []any) ([]any, error) {
+		if args[0].(common.Address) != testAddress {
+			return nil, fmt.Errorf("%s of %s", method, args[0])
+		}
+		switch method {
+		case "balanceOf":
+			return []any{state.balance}, nil
+		case "allowance":
+			if allowance := state.allowances[args[1].(common.Address)]; allowance != nil {
+				return []any{allowance}, nil
+			}
+			return []any{new(big.Int)}, nil
+		}
+		return nil, fmt.Errorf("unexpected call %s", method)
+	})
+	backend.deploy(testContracts.ConditionalTokens, conditionalTokensABI, func(method string, args []any) ([]any, error) {
+		if method != "isApprovedForAll" || args[0].(common.Address) != testAddress {
+			return nil, fmt.Errorf("unexpected call %s", method)
+		}
+		return []any{state.operators[args[1].(common.Address)]}, nil
+	})
+}
+
+// call is the destination and calldata of an expected transaction
+type call struct {
+	to       common.Address
+	calldata []byte
+}
+
+func TestApprovals(t *testing.T) {
+	exchange, negRiskExchange, adapter := testContracts.Exchange, testContracts.NegRiskExchange, testContracts.NegRiskAdapter
+
+	approve := func(t *testing.T, spender common.Address) []byte {
+		return encodeCall(t, "approve(address,uint256)", []string{"address", "uint256"}, spender, math.MaxBig256)
+	}
+	setApprovalForAll := func(t *testing.T, operator common.Address) []byte {
+		return encodeCall(t, "setApprovalForAll(address,bool)", []string{"address", "bool"}, operator, true)
+	}
+
+	tests := []struct {
+		name  string
+		state approvalState
+		// ready lists collateral and operator readiness per spender
+		ready []string
+		txs   func(t *testing.T) []call
+	}{
+		{
+			name: "nothing approved",
+			state: approvalState{
+				balance: big.NewInt(100e6),
+			},
+			ready: []string{"CTF Exchange false false", "Neg Risk CTF Exchange false false", "Neg Risk Adapter false false"},
+			txs: func(t *testing.T) []call {
+				return []call{
+					{testContracts.Collateral, approve(t, exchange)},
+					{testContracts.ConditionalTokens, setApprovalForAll(t, exchange)},
+					{testContracts.Collateral, approve(t, negRiskExchange)},
+					{testContracts.ConditionalTokens, setApprovalForAll(t, negRiskExchange)},
+					{testContracts.Collateral, approve(t, adapter)},
+					{testContracts.ConditionalTokens, setApprovalForAll(t, adapter)},
+				}
+			},
+		},
+		{
+			// An allowance below the balance is not enough
+			name: "partly approved",
+			state: approvalState{
+				balance: big.NewInt(100e6),
+				allowances: map[common.Address]*big.Int{
+					exchange:        math.MaxBig256,
+					negRiskExchange: big.NewInt(50e6),
+				},
+				operators: map[common.Address]bool{exchange: true, adapter: true},
+			},
+			ready: []string{"CTF Exchange true true", "Neg Risk CTF Exchange false false", "Neg Risk Adapter false true"},
+			txs: func(t *testing.T) []call {
+				return []call{
+					{testContracts.Collateral, approve(t, negRiskExchange)},
+					{testContracts.ConditionalTokens, setApprovalForAll(t, negRiskExchange)},
+					{testContracts.Collateral, approve(t, adapter)},
+				}
+			},
+		},
+		{
+			name: "all approved",
+			state: approvalState{
+				balance: big.NewInt(100e6),
+				allowances: map[common.Address]*big.Int{
+					exchange:        big.NewInt(100e6),
+					negRiskExchange: math.MaxBig256,
+					adapter:         math.MaxBig256,
+				},
+				operators: map[common.Address]bool{exchange: true, negRiskExchange: true, adapter: true},
+			},
+			ready: []string{"CTF Exchange true true", "Neg Risk CTF Exchange true true", "Neg Risk Adapter true true"},
+			txs: func(t *testing.T) []call {
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := newTestBackend()
+			backend.nonce = 5
+			deployApprovals(backend, tt.state)
+			client := newTestClient(t, backend)
+
+			approvals, err := client.CheckApprovals(context.Background(), testAddress)
+			if err != nil {
+				t.Fatalf("CheckApprovals failed: %v", err)
+			}
+			var ready []string
+			for _, s := range approvals.Spenders {
+				ready = append(ready, fmt.Sprintf("%s %v %v", s.Name, s.CollateralApproved, s.ApprovedForAll))
+			}
+			if fmt.Sprint(ready) != fmt.Sprint(tt.ready) {
+				t.Errorf("spenders = %q, want %q", ready, tt.ready)
+			}
+			wantTxs := tt.txs(t)
+			if approvals.Ready() != (len(wantTxs) == 0) {
+				t.Errorf("Ready = %v", approvals.Ready())
+			}
+			if approvals.Balance.Cmp(tt.state.balance) != 0 {
+				t.Errorf("balance = %s, want %s", approvals.Balance, tt.state.balance)
+			}
+
+			txs, err := client.SetupApprovals(&TxOptions{NoSend: true})
+			if err != nil {
+				t.Fatalf("SetupApprovals failed: %v", err)
+			}
+			if len(txs) != len(wantTxs) {
+				t.Fatalf("%d transactions, want %d", len(txs), len(wantTxs))
+			}
+			for i, tx := range txs {
+				assertCall(t, tx, wantTxs[i].to, wantTxs[i].calldata)
+				if tx.Nonce() != uint64(5+i) {
+					t.Errorf("transaction %d has nonce %d, want %d", i, tx.Nonce(), 5+i)
+				}
+			}
+			if len(backend.transactions()) != 0 {
+				t.Error("NoSend approvals were sent")
+			}
+		})
+	}
+}
+
+func TestApprovalsAllowances(t *testing.T) {
+	backend := newTestBackend()
+	deployApprovals(backend, approvalState{
+		balance:    big.NewInt(0),
+		allowances: map[common.Address]*big.Int{testContracts.Exchange: big.NewInt(7)},
+	})
+	client := newTestClient(t, backend)
+
+	approvals, err := client.CheckApprovals(context.Background(), testAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	allowances := approvals.Allowances()
+	if len(allowances) != 3 || allowances[testContracts.Exchange.Hex()] != "7" || allowances[testContracts.NegRiskAdapter.Hex()] != "0" {
+		t.Errorf("Allowances = %v", allowances)
+	}
+
+	// With no balance any allowance covers it, but a zero allowance does not
+	if !approvals.Spenders[0].CollateralApproved || approvals.Spenders[2].CollateralApproved {
+		t.Errorf("spenders = %+v", approvals.Spenders)
+	}
+
+	// The spenders must be configured
+	contracts := *testContracts
+	contracts.NegRiskAdapter = common.Address{}
+	client, err = NewClient(&Config{ChainID: types.ChainPolygon, Backend: backend, Contracts: &contracts})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CheckApprovals(context.Background(), testAddress); err == nil {
+		t.Error("CheckApprovals succeeded without a neg risk adapter")
+	}
+}
@@ -0,0 +1,199 @@
+package ctf
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/lixvyang/polymarket-sdk-go/auth"
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// testKey is the first Hardhat/Anvil account
+const testKey = "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+var testAddress = common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+
+// testContracts are the Polygon contracts
+var testContracts = func() *types.ContractConfig {
+	contracts, err := types.GetContractConfig(types.ChainPolygon)
+	if err != nil {
+		panic(err)
+	}
+	return contracts
+}()
+
+// callHandler answers a contract call with the method outputs
+type callHandler func(method string, args []any) ([]any, error)
+
+type testContract struct {
+	abi    abi.ABI
+	handle callHandler
+}
+
+// testBackend is a stand-in for an RPC backend. Calls to registered
+// contracts are decoded with their ABI and answered by a handler, and sent
+// transactions are kept. Methods the tests do not use panic.
+type testBackend struct {
+	bind.ContractBackend
+
+	mu        sync.Mutex
+	contracts map[common.Address]testContract
+	calls     []string
+	sent      []*ethtypes.Transaction
+	nonce     uint64
+}
+
+func newTestBackend() *testBackend {
+	return &testBackend{contracts: make(map[common.Address]testContract)}
+}
+
+// deploy registers a contract at address
+func (b *testBackend) deploy(address common.Address, contractABI abi.ABI, handle callHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.contracts[address] = testContract{abi: contractABI, handle: handle}
+}
+
+// called returns the calls made so far as "address.method"
+func (b *testBackend) called() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]string(nil), b.calls...)
+}
+
+func (b *testBackend) transactions() []*ethtypes.Transaction {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*ethtypes.Transaction(nil), b.sent...)
+}
+
+// contractCode is the code of every address, so gas estimation does not need a
+// registered contract
+var contractCode = []byte{0xfe}
+
+func (b *testBackend) CodeAt(ctx context.Context, address common.Address, blockNumber *big.Int) ([]byte, error) {
+	return contractCode, nil
+}
+
+func (b *testBackend) PendingCodeAt(ctx context.Context, address common.Address) ([]byte, error) {
+	return contractCode, nil
+}
+
+func (b *testBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	b.mu.Lock()
+	contract, ok := b.contracts[*call.To]
+	b.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("no contract at %s", call.To.Hex())
+	}
+
+	method, err := contract.abi.MethodById(call.Data)
+	if err != nil {
+		return nil, err
+	}
+	args, err := method.Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	b.calls = append(b.calls, fmt.Sprintf("%s.%s", call.To.Hex(), method.Name))
+	b.mu.Unlock()
+
+	out, err := contract.handle(method.Name, args)
+	if err != nil {
+		return nil, err
+	}
+	return method.Outputs.Pack(out...)
+}
+
+func (b *testBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*ethtypes.Header, error) {
+	return &ethtypes.Header{Number: big.NewInt(1), BaseFee: big.NewInt(30e9)}, nil
+}
+
+func (b *testBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(30e9), nil
+}
+
+func (b *testBackend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return 100000, nil
+}
+
+func (b *testBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.nonce, nil
+}
+
+func (b *testBackend) SendTransaction(ctx context.Context, tx *ethtypes.Transaction) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sent = append(b.sent, tx)
+	b.nonce++
+	return nil
+}
+
+// newTestClient returns a client of backend signing with testKey
+func newTestClient(t *testing.T, backend *testBackend) *Client {
+	t.Helper()
+
+	wallet, err := auth.NewWalletFromHex(testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client, err := NewClient(&Config{ChainID: types.ChainPolygon, Backend: backend, Signer: wallet})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func selector(signature string) uint32 {
+	return binary.BigEndian.Uint32(crypto.Keccak256([]byte(signature))[:4])
+}
+
+// encodeCall builds the calldata of a function from its signature, without
+// going through the package ABIs
+func encodeCall(t *testing.T, signature string, argTypes []string, args ...any) []byte {
+	t.Helper()
+
+	var arguments abi.Arguments
+	for _, argType := range argTypes {
+		typ, err := abi.NewType(argType, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		arguments = append(arguments, abi.Argument{Type: typ})
+	}
+	packed, err := arguments.Pack(args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(binary.BigEndian.AppendUint32(nil, selector(signature)), packed...)
+}
+
+// assertCall checks the destination, sender and calldata of a transaction
+func assertCall(t *testing.T, tx *ethtypes.Transaction, to common.Address, calldata []byte) {
+	t.Helper()
+
+	if tx.To() == nil || *tx.To() != to {
+		t.Errorf("transaction sent to %v, want %s", tx.To(), to.Hex())
+	}
+	if string(tx.Data()) != string(calldata) {
+		t.Errorf("calldata = %x, want %x", tx.Data(), calldata)
+	}
+	from, err := ethtypes.Sender(ethtypes.LatestSignerForChainID(big.NewInt(int64(types.ChainPolygon))), tx)
+	if err != nil || from != testAddress {
+		t.Errorf("transaction signed by %s, %v, want %s", from.Hex(), err, testAddress.Hex())
+	}
+}
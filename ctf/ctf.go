@@ -0,0 +1,207 @@
+// Package ctf builds, signs and sends ConditionalTokens transactions to split
+// collateral into outcome tokens, merge them back and redeem resolved
+// positions, for both regular and neg risk markets. It also cancels orders on
+// the exchanges directly, for when the CLOB is unavailable.
+package ctf
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/lixvyang/polymarket-sdk-go/auth"
+	"github.com/lixvyang/polymarket-sdk-go/data"
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// tokenDecimals is the number of decimals of USDC and outcome tokens
+const tokenDecimals = 6
+
+// Config configures a CTF client
+type Config struct {
+	ChainID types.Chain
+
+	// Backend is an RPC client such as ethclient.Client, or a simulated backend
+	Backend bind.ContractBackend
+
+	// Signer sends the transactions. It is optional for read-only calls.
+	Signer auth.Signer
+
+	// Contracts overrides the contract addresses registered for ChainID,
+	// e.g. on a local devnet
+	Contracts *types.ContractConfig
+}
+
+// TxOptions tunes a transaction. Zero values are filled in from the backend.
+type TxOptions struct {
+	Context   context.Context
+	Nonce     *uint64
+	GasLimit  uint64
+	GasFeeCap *big.Int
+	GasTipCap *big.Int
+
+	// NoSend signs the transaction without sending it
+	NoSend bool
+}
+
+// Client sends ConditionalTokens, NegRiskAdapter and exchange transactions
+type Client struct {
+	chainID           types.Chain
+	backend           bind.ContractBackend
+	signer            auth.Signer
+	contracts         *types.ContractConfig
+	conditionalTokens *bind.BoundContract
+	negRiskAdapter    *bind.BoundContract
+	collateralToken   *bind.BoundContract
+	exchange          *bind.BoundContract
+	negRiskExchange   *bind.BoundContract
+	collateral        common.Address
+}
+
+// NewClient creates a CTF client
+func NewClient(config *Config) (*Client, error) {
+	if config == nil || config.Backend == nil {
+		return nil, fmt.Errorf("backend is required")
+	}
+
+	contracts := config.Contracts
+	if contracts == nil {
+		var err error
+		contracts, err = types.GetContractConfig(config.ChainID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if contracts.ConditionalTokens == (common.Address{}) || contracts.Collateral == (common.Address{}) {
+		return nil, fmt.Errorf("conditional tokens and collateral addresses are required")
+	}
+
+	backend := config.Backend
+	return &Client{
+		chainID:           config.ChainID,
+		backend:           backend,
+		signer:            config.Signer,
+		contracts:         contracts,
+		conditionalTokens: bind.NewBoundContract(contracts.ConditionalTokens, conditionalTokensABI, backend, backend, backend),
+		negRiskAdapter:    bind.NewBoundContract(contracts.NegRiskAdapter, negRiskAdapterABI, backend, backend, backend),
+		collateralToken:   bind.NewBoundContract(contracts.Collateral, erc20ABI, backend, backend, backend),
+		exchange:          bind.NewBoundContract(contracts.Exchange, exchangeABI, backend, backend, backend),
+		negRiskExchange:   bind.NewBoundContract(contracts.NegRiskExchange, exchangeABI, backend, backend, backend),
+		collateral:        contracts.Collateral,
+	}, nil
+}
+
+// BinaryPartition returns the index sets of the two outcomes of a binary
+// condition: 0b01 and 0b10
+func BinaryPartition() []*big.Int {
+	return []*big.Int{big.NewInt(1), big.NewInt(2)}
+}
+
+// SplitPosition converts amount of collateral, in base units, into amount
+// of each outcome token of a binary condition
+func (c *Client) SplitPosition(conditionID common.Hash, amount *big.Int, negRisk bool, opts *TxOptions) (*ethtypes.Transaction, error) {
+	if negRisk {
+		return c.transact(c.negRiskAdapter, opts, "splitPosition", conditionID, amount)
+	}
+	return c.transact(c.conditionalTokens, opts, "splitPosition", c.collateral, common.Hash{}, conditionID, BinaryPartition(), amount)
+}
+
+// MergePositions converts amount of each outcome token, in base units, back
+// into collateral
+func (c *Client) MergePositions(conditionID common.Hash, amount *big.Int, negRisk bool, opts *TxOptions) (*ethtypes.Transaction, error) {
+	if negRisk {
+		return c.transact(c.negRiskAdapter, opts, "mergePositions", conditionID, amount)
+	}
+	return c.transact(c.conditionalTokens, opts, "mergePositions", c.collateral, common.Hash{}, conditionID, BinaryPartition(), amount)
+}
+
+// RedeemPositions redeems all outcome tokens of a resolved condition held by
+// the signer for collateral
+func (c *Client) RedeemPositions(conditionID common.Hash, opts *TxOptions) (*ethtypes.Transaction, error) {
+	return c.transact(c.conditionalTokens, opts, "redeemPositions", c.collateral, common.Hash{}, conditionID, BinaryPartition())
+}
+
+// RedeemNegRiskPositions redeems outcome tokens of a resolved neg risk
+// condition through the adapter. amounts holds the YES and NO amounts to
+// redeem, in base units.
+func (c *Client) RedeemNegRiskPositions(conditionID common.Hash, amounts []*big.Int, opts *TxOptions) (*ethtypes.Transaction, error) {
+	if len(amounts) != 2 {
+		return nil, fmt.Errorf("neg risk redemption needs 2 amounts, got %d", len(amounts))
+	}
+	return c.transact(c.negRiskAdapter, opts, "redeemPositions", conditionID, amounts)
+}
+
+// RedeemPosition redeems a redeemable Data API position held by the signer
+func (c *Client) RedeemPosition(position data.Position, opts *TxOptions) (*ethtypes.Transaction, error) {
+	if !position.Redeemable {
+		return nil, fmt.Errorf("position %s is not redeemable", position.Asset)
+	}
+	if len(common.FromHex(position.ConditionID)) != common.HashLength {
+		return nil, fmt.Errorf("invalid condition id %q", position.ConditionID)
+	}
+	conditionID := common.HexToHash(position.ConditionID)
+
+	if position.NegativeRisk != nil && *position.NegativeRisk {
+		if position.OutcomeIndex < 0 || position.OutcomeIndex > 1 {
+			return nil, fmt.Errorf("invalid outcome index %d", position.OutcomeIndex)
+		}
+		amounts := []*big.Int{new(big.Int), new(big.Int)}
+		// Truncate so the amount never exceeds the on-chain balance
+		amounts[position.OutcomeIndex] = position.Size.Truncate(tokenDecimals).Units(tokenDecimals)
+		return c.RedeemNegRiskPositions(conditionID, amounts, opts)
+	}
+
+	return c.RedeemPositions(conditionID, opts)
+}
+
+// BalanceOf returns the balance of an outcome token (position ID) of an owner
+func (c *Client) BalanceOf(ctx context.Context, owner common.Address, positionID *big.Int) (*big.Int, error) {
+	balance, err := callUint(ctx, c.conditionalTokens, "balanceOf", owner, positionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance: %w", err)
+	}
+	return balance, nil
+}
+
+// callUint calls a view method returning a single uint256
+func callUint(ctx context.Context, contract *bind.BoundContract, method string, params ...any) (*big.Int, error) {
+	var out []any
+	if err := contract.Call(&bind.CallOpts{Context: ctx}, &out, method, params...); err != nil {
+		return nil, err
+	}
+
+	value, ok := out[0].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %s result type %T", method, out[0])
+	}
+	return value, nil
+}
+
+// transact builds and signs a transaction, and sends it unless NoSend is set
+func (c *Client) transact(contract *bind.BoundContract, opts *TxOptions, method string, params ...any) (*ethtypes.Transaction, error) {
+	if c.signer == nil {
+		return nil, fmt.Errorf("signer is required to send transactions")
+	}
+
+	transactOpts := auth.NewTransactOpts(c.signer, big.NewInt(int64(c.chainID)))
+	if opts != nil {
+		transactOpts.Context = opts.Context
+		transactOpts.GasLimit = opts.GasLimit
+		transactOpts.GasFeeCap = opts.GasFeeCap
+		transactOpts.GasTipCap = opts.GasTipCap
+		transactOpts.NoSend = opts.NoSend
+		if opts.Nonce != nil {
+			transactOpts.Nonce = new(big.Int).SetUint64(*opts.Nonce)
+		}
+	}
+
+	tx, err := contract.Transact(transactOpts, method, params...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send %s: %w", method, err)
+	}
+
+	return tx, nil
+}
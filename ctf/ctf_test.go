@@ -0,0 +1,219 @@
+package ctf
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/lixvyang/polymarket-sdk-go/data"
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+var testConditionID = common.HexToHash("0xbd31dc8a20211944f6b70f31557f1001557b59905b7738480ca09bd4532f84af")
+
+func TestSplitMergeRedeem(t *testing.T) {
+	ctfArgs := []string{"address", "bytes32", "bytes32", "uint256[]", "uint256"}
+	adapterArgs := []string{"bytes32", "uint256"}
+	partition := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	collateral := testContracts.Collateral
+	negRisk := true
+
+	tests := []struct {
+		name     string
+		build    func(c *Client, opts *TxOptions) (*ethtypes.Transaction, error)
+		to       common.Address
+		calldata func(t *testing.T) []byte
+	}{
+		{
+			name: "split",
+			build: func(c *Client, opts *TxOptions) (*ethtypes.Transaction, error) {
+				return c.SplitPosition(testConditionID, big.NewInt(10e6), false, opts)
+			},
+			to: testContracts.ConditionalTokens,
+			calldata: func(t *testing.T) []byte {
+				return encodeCall(t, "splitPosition(address,bytes32,bytes32,uint256[],uint256)", ctfArgs,
+					collateral, common.Hash{}, testConditionID, partition, big.NewInt(10e6))
+			},
+		},
+		{
+			name: "merge",
+			build: func(c *Client, opts *TxOptions) (*ethtypes.Transaction, error) {
+				return c.MergePositions(testConditionID, big.NewInt(4e6), false, opts)
+			},
+			to: testContracts.ConditionalTokens,
+			calldata: func(t *testing.T) []byte {
+				return encodeCall(t, "mergePositions(address,bytes32,bytes32,uint256[],uint256)", ctfArgs,
+					collateral, common.Hash{}, testConditionID, partition, big.NewInt(4e6))
+			},
+		},
+		{
+			name: "redeem",
+			build: func(c *Client, opts *TxOptions) (*ethtypes.Transaction, error) {
+				return c.RedeemPositions(testConditionID, opts)
+			},
+			to: testContracts.ConditionalTokens,
+			calldata: func(t *testing.T) []byte {
+				return encodeCall(t, "redeemPositions(address,bytes32,bytes32,uint256[])", ctfArgs[:4],
+					collateral, common.Hash{}, testConditionID, partition)
+			},
+		},
+		{
+			name: "redeem position",
+			build: func(c *Client, opts *TxOptions) (*ethtypes.Transaction, error) {
+				return c.RedeemPosition(data.Position{ConditionID: testConditionID.Hex(), Size: types.MustDecimal("3"), Redeemable: true}, opts)
+			},
+			to: testContracts.ConditionalTokens,
+			calldata: func(t *testing.T) []byte {
+				return encodeCall(t, "redeemPositions(address,bytes32,bytes32,uint256[])", ctfArgs[:4],
+					collateral, common.Hash{}, testConditionID, partition)
+			},
+		},
+		{
+			name: "neg risk split",
+			build: func(c *Client, opts *TxOptions) (*ethtypes.Transaction, error) {
+				return c.SplitPosition(testConditionID, big.NewInt(10e6), true, opts)
+			},
+			to: testContracts.NegRiskAdapter,
+			calldata: func(t *testing.T) []byte {
+				return encodeCall(t, "splitPosition(bytes32,uint256)", adapterArgs, testConditionID, big.NewInt(10e6))
+			},
+		},
+		{
+			name: "neg risk merge",
+			build: func(c *Client, opts *TxOptions) (*ethtypes.Transaction, error) {
+				return c.MergePositions(testConditionID, big.NewInt(4000001), true, opts)
+			},
+			to: testContracts.NegRiskAdapter,
+			calldata: func(t *testing.T) []byte {
+				return encodeCall(t, "mergePositions(bytes32,uint256)", adapterArgs, testConditionID, big.NewInt(4000001))
+			},
+		},
+		{
+			name: "neg risk redeem",
+			build: func(c *Client, opts *TxOptions) (*ethtypes.Transaction, error) {
+				return c.RedeemNegRiskPositions(testConditionID, []*big.Int{big.NewInt(999999), big.NewInt(0)}, opts)
+			},
+			to: testContracts.NegRiskAdapter,
+			calldata: func(t *testing.T) []byte {
+				return encodeCall(t, "redeemPositions(bytes32,uint256[])", []string{"bytes32", "uint256[]"},
+					testConditionID, []*big.Int{big.NewInt(999999), big.NewInt(0)})
+			},
+		},
+		{
+			// The Data API reports sizes with more precision than the token has:
+			// 5.0000006 must redeem 5000000 units, not round up past the balance
+			name: "neg risk redeem position",
+			build: func(c *Client, opts *TxOptions) (*ethtypes.Transaction, error) {
+				return c.RedeemPosition(data.Position{
+					ConditionID:  testConditionID.Hex(),
+					Size:         types.MustDecimal("5.0000006"),
+					Redeemable:   true,
+					OutcomeIndex: 1,
+					NegativeRisk: &negRisk,
+				}, opts)
+			},
+			to: testContracts.NegRiskAdapter,
+			calldata: func(t *testing.T) []byte {
+				return encodeCall(t, "redeemPositions(bytes32,uint256[])", []string{"bytes32", "uint256[]"},
+					testConditionID, []*big.Int{big.NewInt(0), big.NewInt(5e6)})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := newTestBackend()
+			client := newTestClient(t, backend)
+
+			tx, err := tt.build(client, &TxOptions{NoSend: true})
+			if err != nil {
+				t.Fatalf("building the transaction failed: %v", err)
+			}
+			assertCall(t, tx, tt.to, tt.calldata(t))
+			if len(backend.transactions()) != 0 {
+				t.Error("NoSend transaction was sent")
+			}
+
+			// Without NoSend the same transaction is sent
+			sent, err := tt.build(client, nil)
+			if err != nil {
+				t.Fatalf("sending the transaction failed: %v", err)
+			}
+			if txs := backend.transactions(); len(txs) != 1 || txs[0].Hash() != sent.Hash() {
+				t.Fatalf("sent %d transactions, want 1", len(txs))
+			}
+			assertCall(t, sent, tt.to, tt.calldata(t))
+		})
+	}
+}
+
+func TestTxOptions(t *testing.T) {
+	client := newTestClient(t, newTestBackend())
+
+	nonce := uint64(7)
+	tx, err := client.SplitPosition(testConditionID, big.NewInt(1), false, &TxOptions{
+		Nonce:     &nonce,
+		GasLimit:  250000,
+		GasFeeCap: big.NewInt(100e9),
+		GasTipCap: big.NewInt(40e9),
+		NoSend:    true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Nonce() != 7 || tx.Gas() != 250000 || tx.GasFeeCap().Int64() != 100e9 || tx.GasTipCap().Int64() != 40e9 {
+		t.Errorf("nonce %d, gas %d, fee cap %s, tip cap %s", tx.Nonce(), tx.Gas(), tx.GasFeeCap(), tx.GasTipCap())
+	}
+	if tx.ChainId().Int64() != int64(types.ChainPolygon) {
+		t.Errorf("chain ID = %s, want %d", tx.ChainId(), types.ChainPolygon)
+	}
+
+	readOnly, err := NewClient(&Config{ChainID: types.ChainPolygon, Backend: newTestBackend()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readOnly.SplitPosition(testConditionID, big.NewInt(1), false, nil); err == nil {
+		t.Error("SplitPosition succeeded without a signer")
+	}
+}
+
+func TestBalanceOf(t *testing.T) {
+	backend := newTestBackend()
+	positionID := big.NewInt(42)
+	backend.deploy(testContracts.ConditionalTokens, conditionalTokensABI, func(method string, args []any) ([]any, error) {
+		if method == "balanceOf" && args[0].(common.Address) == testAddress && args[1].(*big.Int).Cmp(positionID) == 0 {
+			return []any{big.NewInt(6e6)}, nil
+		}
+		return []any{new(big.Int)}, nil
+	})
+	client := newTestClient(t, backend)
+
+	balance, err := client.BalanceOf(context.Background(), testAddress, positionID)
+	if err != nil {
+		t.Fatalf("BalanceOf failed: %v", err)
+	}
+	if balance.Cmp(big.NewInt(6e6)) != 0 {
+		t.Errorf("BalanceOf = %s, want 6000000", balance)
+	}
+}
+
+func TestRedeemNegRiskPositionsRejectsBadAmounts(t *testing.T) {
+	client := newTestClient(t, newTestBackend())
+	negRisk := true
+
+	if _, err := client.RedeemNegRiskPositions(testConditionID, []*big.Int{big.NewInt(1)}, nil); err == nil {
+		t.Error("RedeemNegRiskPositions accepted a single amount")
+	}
+	if _, err := client.RedeemPosition(data.Position{ConditionID: testConditionID.Hex()}, nil); err == nil {
+		t.Error("RedeemPosition accepted a position that is not redeemable")
+	}
+	if _, err := client.RedeemPosition(data.Position{ConditionID: "0x1234", Redeemable: true}, nil); err == nil {
+		t.Error("RedeemPosition accepted an invalid condition id")
+	}
+	position := data.Position{ConditionID: testConditionID.Hex(), Redeemable: true, OutcomeIndex: 2, NegativeRisk: &negRisk}
+	if _, err := client.RedeemPosition(position, nil); err == nil {
+		t.Error("RedeemPosition accepted an invalid outcome index")
+	}
+}
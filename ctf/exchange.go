@@ -0,0 +1,157 @@
+package ctf
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/lixvyang/polymarket-sdk-go/auth"
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// exchangeOrder is the Order struct of the exchange contracts
+type exchangeOrder struct {
+	Salt          *big.Int
+	Maker         common.Address
+	Signer        common.Address
+	Taker         common.Address
+	TokenId       *big.Int
+	MakerAmount   *big.Int
+	TakerAmount   *big.Int
+	Expiration    *big.Int
+	Nonce         *big.Int
+	FeeRateBps    *big.Int
+	Side          uint8
+	SignatureType uint8
+	Signature     []byte
+}
+
+// newExchangeOrder converts a signed order to its contract representation
+func newExchangeOrder(order *types.SignedOrder) (exchangeOrder, error) {
+	side, err := auth.OrderSide(order.Side)
+	if err != nil {
+		return exchangeOrder{}, err
+	}
+
+	// The exchange does not check signatures on cancellation
+	var signature []byte
+	if order.Signature != "" {
+		signature, err = hexutil.Decode(order.Signature)
+		if err != nil {
+			return exchangeOrder{}, fmt.Errorf("invalid signature: %w", err)
+		}
+	}
+
+	out := exchangeOrder{
+		Maker:         common.HexToAddress(order.Maker),
+		Signer:        common.HexToAddress(order.Signer),
+		Taker:         common.HexToAddress(order.Taker),
+		MakerAmount:   order.MakerAmount,
+		TakerAmount:   order.TakerAmount,
+		Side:          side,
+		SignatureType: uint8(order.SignatureType),
+		Signature:     signature,
+	}
+	if out.MakerAmount == nil || out.TakerAmount == nil {
+		return exchangeOrder{}, fmt.Errorf("maker and taker amounts are required")
+	}
+
+	numbers := []struct {
+		name  string
+		value string
+		out   **big.Int
+	}{
+		{"salt", order.Salt, &out.Salt},
+		{"token id", order.TokenID, &out.TokenId},
+		{"expiration", order.Expiration, &out.Expiration},
+		{"nonce", order.Nonce, &out.Nonce},
+		{"fee rate", order.FeeRateBps, &out.FeeRateBps},
+	}
+	for _, n := range numbers {
+		value, ok := new(big.Int).SetString(n.value, 10)
+		if !ok {
+			return exchangeOrder{}, fmt.Errorf("invalid %s %q", n.name, n.value)
+		}
+		*n.out = value
+	}
+
+	return out, nil
+}
+
+// exchangeContract returns the exchange of regular or neg risk markets
+func (c *Client) exchangeContract(negRisk bool) (*bind.BoundContract, error) {
+	if c.contracts.ExchangeAddress(negRisk) == (common.Address{}) {
+		return nil, fmt.Errorf("exchange address is not configured")
+	}
+	if negRisk {
+		return c.negRiskExchange, nil
+	}
+	return c.exchange, nil
+}
+
+// CancelOrder cancels a signed order on the exchange, without going through
+// the CLOB. The signer must be the order maker, so orders of proxy wallets
+// and Safes have to be cancelled from those contracts.
+func (c *Client) CancelOrder(order *types.SignedOrder, negRisk bool, opts *TxOptions) (*ethtypes.Transaction, error) {
+	exchange, err := c.exchangeContract(negRisk)
+	if err != nil {
+		return nil, err
+	}
+
+	converted, err := newExchangeOrder(order)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.transact(exchange, opts, "cancelOrder", converted)
+}
+
+// CancelOrders cancels signed orders of one exchange in a single transaction
+func (c *Client) CancelOrders(orders []*types.SignedOrder, negRisk bool, opts *TxOptions) (*ethtypes.Transaction, error) {
+	exchange, err := c.exchangeContract(negRisk)
+	if err != nil {
+		return nil, err
+	}
+
+	converted := make([]exchangeOrder, 0, len(orders))
+	for i, order := range orders {
+		o, err := newExchangeOrder(order)
+		if err != nil {
+			return nil, fmt.Errorf("order %d: %w", i, err)
+		}
+		converted = append(converted, o)
+	}
+
+	return c.transact(exchange, opts, "cancelOrders", converted)
+}
+
+// IncrementNonce increments the exchange nonce of the signer, invalidating
+// all of its orders signed with the previous nonce. Each exchange keeps its
+// own nonces.
+func (c *Client) IncrementNonce(negRisk bool, opts *TxOptions) (*ethtypes.Transaction, error) {
+	exchange, err := c.exchangeContract(negRisk)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.transact(exchange, opts, "incrementNonce")
+}
+
+// Nonce returns the current exchange nonce of a maker. Orders are only valid
+// when signed with this nonce.
+func (c *Client) Nonce(ctx context.Context, maker common.Address, negRisk bool) (*big.Int, error) {
+	exchange, err := c.exchangeContract(negRisk)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := callUint(ctx, exchange, "nonces", maker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %w", err)
+	}
+	return nonce, nil
+}
@@ -0,0 +1,218 @@
+package ctf
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// orderSignature is the Order tuple of the exchange contracts
+const orderSignature = "(uint256,address,address,address,uint256,uint256,uint256,uint256,uint256,uint256,uint8,uint8,bytes)"
+
+// orderTuple holds the fields of the Order tuple, in order
+type orderTuple struct {
+	Salt          *big.Int
+	Maker         common.Address
+	Signer        common.Address
+	Taker         common.Address
+	TokenId       *big.Int
+	MakerAmount   *big.Int
+	TakerAmount   *big.Int
+	Expiration    *big.Int
+	Nonce         *big.Int
+	FeeRateBps    *big.Int
+	Side          uint8
+	SignatureType uint8
+	Signature     []byte
+}
+
+// encodeOrders builds the calldata of cancelOrder or cancelOrders from an
+// Order tuple type declared here, independently of the package ABI
+func encodeOrders(t *testing.T, signature string, array bool, orders ...orderTuple) []byte {
+	t.Helper()
+
+	names := []string{"salt", "maker", "signer", "taker", "tokenId", "makerAmount", "takerAmount",
+		"expiration", "nonce", "feeRateBps", "side", "signatureType", "signature"}
+	fieldTypes := []string{"uint256", "address", "address", "address", "uint256", "uint256", "uint256",
+		"uint256", "uint256", "uint256", "uint8", "uint8", "bytes"}
+	var components []abi.ArgumentMarshaling
+	for i, name := range names {
+		components = append(components, abi.ArgumentMarshaling{Name: name, Type: fieldTypes[i]})
+	}
+
+	typeName := "tuple"
+	var arg any = orders[0]
+	if array {
+		typeName = "tuple[]"
+		arg = orders
+	}
+	typ, err := abi.NewType(typeName, "", components)
+	if err != nil {
+		t.Fatal(err)
+	}
+	packed, err := abi.Arguments{{Type: typ}}.Pack(arg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(binary.BigEndian.AppendUint32(nil, selector(signature)), packed...)
+}
+
+// testOrder returns a SELL order of a Safe and its tuple. The exchange does
+// not check signatures on cancellation, so any 65 bytes will do.
+func testOrder(salt int64) (*types.SignedOrder, orderTuple) {
+	safe := common.HexToAddress("0xd93B25cb943D14d0d34FBaF01Fc93a0f8b5F6E47")
+	tokenID, _ := new(big.Int).SetString("71321045679252212594626385532706912750332728571942532289631379312455583992563", 10)
+	signature := hexutil.MustDecode("0x302cd9abd0b5fcaa202a344437ec0b6660da984e24ae9ad915a592a90facf5a5" +
+		"1bb8a873cd8d270f070217fea1986531d5eec66f1162a81f66e026db653bf7ce1c")
+
+	order := &types.SignedOrder{
+		Salt:          fmt.Sprint(salt),
+		Maker:         safe.Hex(),
+		Signer:        testAddress.Hex(),
+		Taker:         "0x0000000000000000000000000000000000000000",
+		TokenID:       tokenID.String(),
+		MakerAmount:   big.NewInt(100000000),
+		TakerAmount:   big.NewInt(50000000),
+		Expiration:    "1735689600",
+		Nonce:         "3",
+		FeeRateBps:    "100",
+		Side:          types.SideSell,
+		SignatureType: types.SignatureTypePolyGnosisSafe,
+		Signature:     hexutil.Encode(signature),
+	}
+	tuple := orderTuple{
+		Salt:          big.NewInt(salt),
+		Maker:         safe,
+		Signer:        testAddress,
+		TokenId:       tokenID,
+		MakerAmount:   big.NewInt(100000000),
+		TakerAmount:   big.NewInt(50000000),
+		Expiration:    big.NewInt(1735689600),
+		Nonce:         big.NewInt(3),
+		FeeRateBps:    big.NewInt(100),
+		Side:          1,
+		SignatureType: 2,
+		Signature:     signature,
+	}
+	return order, tuple
+}
+
+func TestCancelOrders(t *testing.T) {
+	first, firstTuple := testOrder(479249096354)
+	second, secondTuple := testOrder(1)
+	second.Side = types.SideBuy
+	second.Signature = ""
+	secondTuple.Side = 0
+	secondTuple.Signature = []byte{}
+
+	cancelOrder := "cancelOrder(" + orderSignature + ")"
+	cancelOrders := "cancelOrders(" + orderSignature + "[])"
+
+	for _, negRisk := range []bool{false, true} {
+		t.Run(fmt.Sprintf("neg risk %v", negRisk), func(t *testing.T) {
+			client := newTestClient(t, newTestBackend())
+			exchange := testContracts.ExchangeAddress(negRisk)
+
+			tx, err := client.CancelOrder(first, negRisk, &TxOptions{NoSend: true})
+			if err != nil {
+				t.Fatalf("CancelOrder failed: %v", err)
+			}
+			assertCall(t, tx, exchange, encodeOrders(t, cancelOrder, false, firstTuple))
+
+			// Cancelling without a signature is allowed
+			tx, err = client.CancelOrder(second, negRisk, &TxOptions{NoSend: true})
+			if err != nil {
+				t.Fatalf("CancelOrder failed: %v", err)
+			}
+			assertCall(t, tx, exchange, encodeOrders(t, cancelOrder, false, secondTuple))
+
+			tx, err = client.CancelOrders([]*types.SignedOrder{first, second}, negRisk, &TxOptions{NoSend: true})
+			if err != nil {
+				t.Fatalf("CancelOrders failed: %v", err)
+			}
+			assertCall(t, tx, exchange, encodeOrders(t, cancelOrders, true, firstTuple, secondTuple))
+
+			tx, err = client.IncrementNonce(negRisk, &TxOptions{NoSend: true})
+			if err != nil {
+				t.Fatalf("IncrementNonce failed: %v", err)
+			}
+			assertCall(t, tx, exchange, binary.BigEndian.AppendUint32(nil, selector("incrementNonce()")))
+		})
+	}
+}
+
+func TestCancelOrderInvalid(t *testing.T) {
+	client := newTestClient(t, newTestBackend())
+
+	tests := []struct {
+		name   string
+		modify func(order *types.SignedOrder)
+	}{
+		{"side", func(order *types.SignedOrder) { order.Side = "HOLD" }},
+		{"salt", func(order *types.SignedOrder) { order.Salt = "0x10" }},
+		{"token id", func(order *types.SignedOrder) { order.TokenID = "" }},
+		{"nonce", func(order *types.SignedOrder) { order.Nonce = "-" }},
+		{"maker amount", func(order *types.SignedOrder) { order.MakerAmount = nil }},
+		{"signature", func(order *types.SignedOrder) { order.Signature = "0xzz" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, _ := testOrder(1)
+			tt.modify(order)
+			if _, err := client.CancelOrder(order, false, &TxOptions{NoSend: true}); err == nil {
+				t.Error("CancelOrder accepted an invalid order")
+			}
+			if _, err := client.CancelOrders([]*types.SignedOrder{order}, false, &TxOptions{NoSend: true}); err == nil {
+				t.Error("CancelOrders accepted an invalid order")
+			}
+		})
+	}
+
+	// The exchanges must be configured
+	contracts := *testContracts
+	contracts.NegRiskExchange = common.Address{}
+	client, err := NewClient(&Config{ChainID: types.ChainPolygon, Backend: newTestBackend(), Contracts: &contracts})
+	if err != nil {
+		t.Fatal(err)
+	}
+	order, _ := testOrder(1)
+	if _, err := client.CancelOrder(order, true, nil); err == nil {
+		t.Error("CancelOrder succeeded without a neg risk exchange")
+	}
+	if _, err := client.IncrementNonce(true, nil); err == nil {
+		t.Error("IncrementNonce succeeded without a neg risk exchange")
+	}
+}
+
+func TestNonce(t *testing.T) {
+	backend := newTestBackend()
+	for address, nonce := range map[common.Address]int64{testContracts.Exchange: 3, testContracts.NegRiskExchange: 9} {
+		backend.deploy(address, exchangeABI, func(method string, args []any) ([]any, error) {
+			if method != "nonces" {
+				return nil, fmt.Errorf("unexpected call %s", method)
+			}
+			if args[0].(common.Address) != testAddress {
+				return []any{new(big.Int)}, nil
+			}
+			return []any{big.NewInt(nonce)}, nil
+		})
+	}
+	client := newTestClient(t, backend)
+
+	for negRisk, want := range map[bool]int64{false: 3, true: 9} {
+		nonce, err := client.Nonce(context.Background(), testAddress, negRisk)
+		if err != nil {
+			t.Fatalf("Nonce failed: %v", err)
+		}
+		if nonce.Int64() != want {
+			t.Errorf("neg risk %v: nonce %s, want %d", negRisk, nonce, want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package ctf
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/bn256"
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+var (
+	// fieldModulus is the alt_bn128 base field modulus used by ConditionalTokens
+	fieldModulus, _ = new(big.Int).SetString("21888242871839275222246405745257275088696311157297823662689037894645226208583", 10)
+	// sqrtExponent is (P+1)/4, as P = 3 mod 4
+	sqrtExponent = new(big.Int).Rsh(new(big.Int).Add(fieldModulus, big.NewInt(1)), 2)
+	curveB       = big.NewInt(3)
+)
+
+// ConditionID returns the ID of the condition prepared by oracle for questionID
+func ConditionID(oracle common.Address, questionID common.Hash, outcomeSlotCount int) common.Hash {
+	return crypto.Keccak256Hash(
+		oracle.Bytes(),
+		questionID.Bytes(),
+		common.BigToHash(big.NewInt(int64(outcomeSlotCount))).Bytes(),
+	)
+}
+
+// NegRiskConditionID returns the ID of the condition prepared by the neg risk
+// adapter for questionID
+func NegRiskConditionID(negRiskAdapter common.Address, questionID common.Hash) common.Hash {
+	return ConditionID(negRiskAdapter, questionID, 2)
+}
+
+// CollectionID returns the ID of the outcome collection indexSet of a
+// condition, nested in parentCollectionID (zero for collateral). It mirrors
+// CTHelpers.getCollectionId, which maps the hash onto an alt_bn128 point so
+// that nested collections commute.
+func CollectionID(parentCollectionID, conditionID common.Hash, indexSet *big.Int) (common.Hash, error) {
+	if indexSet == nil || indexSet.Sign() <= 0 {
+		return common.Hash{}, fmt.Errorf("invalid index set %v", indexSet)
+	}
+
+	x1 := new(big.Int).SetBytes(crypto.Keccak256(conditionID.Bytes(), common.BigToHash(indexSet).Bytes()))
+	odd := x1.Bit(255) == 1
+
+	var y1 *big.Int
+	yy := new(big.Int)
+	for {
+		x1.Add(x1, big.NewInt(1))
+		x1.Mod(x1, fieldModulus)
+		curveY2(yy, x1)
+		y1 = new(big.Int).Exp(yy, sqrtExponent, fieldModulus)
+		if new(big.Int).Exp(y1, big.NewInt(2), fieldModulus).Cmp(yy) == 0 {
+			break
+		}
+	}
+	if odd != (y1.Bit(0) == 1) {
+		y1.Sub(fieldModulus, y1)
+	}
+
+	x2 := parentCollectionID.Big()
+	if x2.Sign() != 0 {
+		odd = x2.Bit(255) == 1 || x2.Bit(254) == 1
+		x2.SetBit(x2, 255, 0)
+		x2.SetBit(x2, 254, 0)
+		curveY2(yy, x2)
+		y2 := new(big.Int).Exp(yy, sqrtExponent, fieldModulus)
+		if odd != (y2.Bit(0) == 1) {
+			y2.Sub(fieldModulus, y2)
+		}
+		if new(big.Int).Exp(y2, big.NewInt(2), fieldModulus).Cmp(yy) != 0 {
+			return common.Hash{}, fmt.Errorf("invalid parent collection ID %s", parentCollectionID.Hex())
+		}
+
+		var err error
+		x1, y1, err = addPoints(x1, y1, x2, y2)
+		if err != nil {
+			return common.Hash{}, err
+		}
+	}
+
+	if y1.Bit(0) == 1 {
+		x1.SetBit(x1, 254, x1.Bit(254)^1)
+	}
+
+	return common.BigToHash(x1), nil
+}
+
+// PositionID returns the ERC1155 token ID of an outcome collection backed by
+// collateral. This is the token ID of CLOB orders and Gamma ClobTokenIDs.
+func PositionID(collateral common.Address, collectionID common.Hash) *big.Int {
+	return new(big.Int).SetBytes(crypto.Keccak256(collateral.Bytes(), collectionID.Bytes()))
+}
+
+// TokenIDs returns the token IDs of the two outcomes of a binary condition,
+// in the order of the market outcomes. Neg risk positions are backed by the
+// wrapped collateral of the neg risk adapter.
+func TokenIDs(contracts *types.ContractConfig, conditionID common.Hash, negRisk bool) ([]*big.Int, error) {
+	collateral := contracts.Collateral
+	if negRisk {
+		collateral = contracts.WrappedCollateral
+	}
+	if collateral == (common.Address{}) {
+		return nil, fmt.Errorf("collateral address is not configured")
+	}
+
+	var tokenIDs []*big.Int
+	for _, indexSet := range BinaryPartition() {
+		collectionID, err := CollectionID(common.Hash{}, conditionID, indexSet)
+		if err != nil {
+			return nil, err
+		}
+		tokenIDs = append(tokenIDs, PositionID(collateral, collectionID))
+	}
+
+	return tokenIDs, nil
+}
+
+// VerifyTokenIDs checks that tokenIDs, e.g. Gamma ClobTokenIDs, are the
+// outcome tokens of a binary condition
+func VerifyTokenIDs(contracts *types.ContractConfig, conditionID common.Hash, negRisk bool, tokenIDs []string) error {
+	expected, err := TokenIDs(contracts, conditionID, negRisk)
+	if err != nil {
+		return err
+	}
+	if len(tokenIDs) != len(expected) {
+		return fmt.Errorf("expected %d token ids, got %d", len(expected), len(tokenIDs))
+	}
+
+	for i, tokenID := range tokenIDs {
+		if tokenID != expected[i].String() {
+			return fmt.Errorf("token id %d of condition %s is %s, expected %s", i, conditionID.Hex(), tokenID, expected[i])
+		}
+	}
+
+	return nil
+}
+
+// curveY2 sets yy to x^3 + 3 mod P
+func curveY2(yy, x *big.Int) {
+	yy.Exp(x, big.NewInt(3), fieldModulus)
+	yy.Add(yy, curveB)
+	yy.Mod(yy, fieldModulus)
+}
+
+// addPoints adds two alt_bn128 points, as the ecAdd precompile does
+func addPoints(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int, error) {
+	var a, b, sum bn256.G1
+	if _, err := a.Unmarshal(append(common.BigToHash(x1).Bytes(), common.BigToHash(y1).Bytes()...)); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode collection point: %w", err)
+	}
+	if _, err := b.Unmarshal(append(common.BigToHash(x2).Bytes(), common.BigToHash(y2).Bytes()...)); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode parent collection point: %w", err)
+	}
+
+	sum.Add(&a, &b)
+	out := sum.Marshal()
+	return new(big.Int).SetBytes(out[:32]), new(big.Int).SetBytes(out[32:64]), nil
+}
@@ -0,0 +1,82 @@
+package ctf
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// Polygon markets with their Gamma conditionId and clobTokenIds
+var testMarkets = []struct {
+	name        string
+	conditionID string
+	negRisk     bool
+	tokenIDs    []string
+}{
+	{
+		name:        "regular",
+		conditionID: "0x4319532e181605cb15b1bd677759a3bc7f7394b2fdf145195b700eeaedfd5221",
+		tokenIDs: []string{
+			"60487116984468020978247225474488676749601001829886755968952521846780452448915",
+			"81104637750588840860328515305303028259865221573278091453716127842023614249200",
+		},
+	},
+	{
+		name:        "neg risk",
+		conditionID: "0xdd22472e552920b8438158ea7238bfadfa4f736aa4cee91a6b86c39ead110917",
+		negRisk:     true,
+		tokenIDs: []string{
+			"21742633143463906290569050155826241533067272736897614950488156847949938836455",
+			"48331043336612883890938759509493159234755048973500640148014422747788308965732",
+		},
+	},
+}
+
+func TestTokenIDs(t *testing.T) {
+	contracts, err := types.GetContractConfig(types.ChainPolygon)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range testMarkets {
+		t.Run(tt.name, func(t *testing.T) {
+			conditionID := common.HexToHash(tt.conditionID)
+			collateral := contracts.Collateral
+			if tt.negRisk {
+				collateral = contracts.WrappedCollateral
+			}
+
+			// Derive each token step by step, then through the helpers
+			for i, indexSet := range BinaryPartition() {
+				collectionID, err := CollectionID(common.Hash{}, conditionID, indexSet)
+				if err != nil {
+					t.Fatalf("CollectionID failed: %v", err)
+				}
+				if got := PositionID(collateral, collectionID).String(); got != tt.tokenIDs[i] {
+					t.Errorf("PositionID of index set %s = %s, want %s", indexSet, got, tt.tokenIDs[i])
+				}
+			}
+
+			tokenIDs, err := TokenIDs(contracts, conditionID, tt.negRisk)
+			if err != nil {
+				t.Fatalf("TokenIDs failed: %v", err)
+			}
+			for i, tokenID := range tokenIDs {
+				if tokenID.String() != tt.tokenIDs[i] {
+					t.Errorf("TokenIDs[%d] = %s, want %s", i, tokenID, tt.tokenIDs[i])
+				}
+			}
+
+			if err := VerifyTokenIDs(contracts, conditionID, tt.negRisk, tt.tokenIDs); err != nil {
+				t.Errorf("VerifyTokenIDs failed: %v", err)
+			}
+			if err := VerifyTokenIDs(contracts, conditionID, !tt.negRisk, tt.tokenIDs); err == nil {
+				t.Error("VerifyTokenIDs accepted the token ids with the wrong collateral")
+			}
+			if err := VerifyTokenIDs(contracts, conditionID, tt.negRisk, []string{tt.tokenIDs[1], tt.tokenIDs[0]}); err == nil {
+				t.Error("VerifyTokenIDs accepted swapped token ids")
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package ctf
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// yesOrNoIdentifier is the UMA price identifier of the adapter requests
+var yesOrNoIdentifier = common.BytesToHash(common.RightPadBytes([]byte("YES_OR_NO_QUERY"), 32))
+
+// OracleState is the state of a UMA Optimistic Oracle V2 price request
+type OracleState uint8
+
+const (
+	OracleStateInvalid OracleState = iota
+	OracleStateRequested
+	OracleStateProposed
+	OracleStateExpired
+	OracleStateDisputed
+	OracleStateResolved
+	OracleStateSettled
+)
+
+// String returns the name of the oracle state
+func (s OracleState) String() string {
+	switch s {
+	case OracleStateInvalid:
+		return "invalid"
+	case OracleStateRequested:
+		return "requested"
+	case OracleStateProposed:
+		return "proposed"
+	case OracleStateExpired:
+		return "expired"
+	case OracleStateDisputed:
+		return "disputed"
+	case OracleStateResolved:
+		return "resolved"
+	case OracleStateSettled:
+		return "settled"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(s))
+	}
+}
+
+// ResolutionState is the stage of a market in its resolution lifecycle
+type ResolutionState string
+
+const (
+	// ResolutionUnknown means the adapter has no request for the question
+	ResolutionUnknown ResolutionState = "unknown"
+	// ResolutionRequested means the outcome is requested but not proposed yet
+	ResolutionRequested ResolutionState = "requested"
+	// ResolutionProposed means an outcome is proposed and may still be disputed
+	ResolutionProposed ResolutionState = "proposed"
+	// ResolutionDisputed means the proposal is disputed and escalated to the UMA DVM
+	ResolutionDisputed ResolutionState = "disputed"
+	// ResolutionSettled means the oracle has a final outcome that the
+	// adapter has not reported to ConditionalTokens yet
+	ResolutionSettled ResolutionState = "settled"
+	// ResolutionResolved means the payouts are reported and positions can be redeemed
+	ResolutionResolved ResolutionState = "resolved"
+)
+
+// Resolution is the resolution status of a market question
+type Resolution struct {
+	QuestionID  common.Hash
+	ConditionID common.Hash
+	State       ResolutionState
+
+	// OracleState is the state of the current price request
+	OracleState OracleState
+	// RequestTimestamp identifies the current price request (0 if none)
+	RequestTimestamp uint64
+	// Reset reports whether the request was reset after a first dispute
+	Reset bool
+	// Paused reports whether the question was paused by the adapter admin
+	Paused bool
+
+	// PayoutNumerators are the reported payouts per outcome (empty until resolved)
+	PayoutNumerators  []*big.Int
+	PayoutDenominator *big.Int
+}
+
+// Quotable reports whether the outcome is still open: it is requested and no
+// outcome has been proposed yet
+func (r *Resolution) Quotable() bool {
+	return !r.Paused && r.State == ResolutionRequested
+}
+
+// Redeemable reports whether positions of the condition can be redeemed
+func (r *Resolution) Redeemable() bool {
+	return r.State == ResolutionResolved
+}
+
+// questionData is the QuestionData struct of the UMA CTF adapter
+type questionData struct {
+	RequestTimestamp          *big.Int
+	Reward                    *big.Int
+	ProposalBond              *big.Int
+	Liveness                  *big.Int
+	ManualResolutionTimestamp *big.Int
+	Resolved                  bool
+	Paused                    bool
+	Reset                     bool
+	Refund                    bool
+	RewardToken               common.Address
+	Creator                   common.Address
+	AncillaryData             []byte
+}
+
+// GetResolution reads the resolution status of a market from the UMA CTF
+// adapter, the Optimistic Oracle and ConditionalTokens. The adapter defaults
+// to the registered UMA adapter (of neg risk markets if negRisk is set).
+func (c *Client) GetResolution(ctx context.Context, questionID, conditionID common.Hash, negRisk bool) (*Resolution, error) {
+	adapter := c.contracts.UmaAdapter
+	if negRisk {
+		adapter = c.contracts.NegRiskUmaAdapter
+	}
+	return c.GetResolutionFromAdapter(ctx, adapter, questionID, conditionID)
+}
+
+// GetResolutionFromAdapter reads the resolution status of a market resolved
+// by a given UMA CTF adapter, e.g. the Gamma resolvedBy address
+func (c *Client) GetResolutionFromAdapter(ctx context.Context, adapter common.Address, questionID, conditionID common.Hash) (*Resolution, error) {
+	if adapter == (common.Address{}) {
+		return nil, fmt.Errorf("UMA adapter address is not configured")
+	}
+
+	resolution := &Resolution{
+		QuestionID:  questionID,
+		ConditionID: conditionID,
+		State:       ResolutionUnknown,
+	}
+
+	if err := c.readPayouts(ctx, resolution); err != nil {
+		return nil, err
+	}
+
+	umaAdapter := bind.NewBoundContract(adapter, umaCtfAdapterABI, c.backend, c.backend, c.backend)
+	callOpts := &bind.CallOpts{Context: ctx}
+
+	var out []any
+	if err := umaAdapter.Call(callOpts, &out, "getQuestion", questionID); err != nil {
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+	question := *abi.ConvertType(out[0], new(questionData)).(*questionData)
+
+	resolution.Paused = question.Paused
+	resolution.Reset = question.Reset
+	if question.RequestTimestamp != nil {
+		resolution.RequestTimestamp = question.RequestTimestamp.Uint64()
+	}
+
+	if resolution.State == ResolutionResolved || resolution.RequestTimestamp == 0 {
+		return resolution, nil
+	}
+
+	out = nil
+	if err := umaAdapter.Call(callOpts, &out, "optimisticOracle"); err != nil {
+		return nil, fmt.Errorf("failed to get optimistic oracle: %w", err)
+	}
+	oracleAddress, ok := out[0].(common.Address)
+	if !ok {
+		return nil, fmt.Errorf("unexpected optimisticOracle result type %T", out[0])
+	}
+
+	oracle := bind.NewBoundContract(oracleAddress, optimisticOracleABI, c.backend, c.backend, c.backend)
+	out = nil
+	if err := oracle.Call(callOpts, &out, "getState", adapter, yesOrNoIdentifier, question.RequestTimestamp, question.AncillaryData); err != nil {
+		return nil, fmt.Errorf("failed to get oracle state: %w", err)
+	}
+	state, ok := out[0].(uint8)
+	if !ok {
+		return nil, fmt.Errorf("unexpected getState result type %T", out[0])
+	}
+	resolution.OracleState = OracleState(state)
+	resolution.State = resolutionState(resolution.OracleState)
+
+	return resolution, nil
+}
+
+// resolutionState returns the stage of a market whose payouts are not
+// reported yet from the state of its oracle request
+func resolutionState(state OracleState) ResolutionState {
+	switch state {
+	case OracleStateRequested:
+		return ResolutionRequested
+	case OracleStateProposed:
+		return ResolutionProposed
+	case OracleStateDisputed:
+		return ResolutionDisputed
+	case OracleStateExpired, OracleStateResolved, OracleStateSettled:
+		return ResolutionSettled
+	}
+	return ResolutionUnknown
+}
+
+// GetPayouts returns the reported payout numerators and denominator of a
+// condition. The denominator is zero until the condition is resolved.
+func (c *Client) GetPayouts(ctx context.Context, conditionID common.Hash) ([]*big.Int, *big.Int, error) {
+	resolution := &Resolution{ConditionID: conditionID}
+	if err := c.readPayouts(ctx, resolution); err != nil {
+		return nil, nil, err
+	}
+	return resolution.PayoutNumerators, resolution.PayoutDenominator, nil
+}
+
+// readPayouts fills in the payouts of a condition, marking it resolved once
+// they are reported
+func (c *Client) readPayouts(ctx context.Context, resolution *Resolution) error {
+	denominator, err := callUint(ctx, c.conditionalTokens, "payoutDenominator", resolution.ConditionID)
+	if err != nil {
+		return fmt.Errorf("failed to get payout denominator: %w", err)
+	}
+	resolution.PayoutDenominator = denominator
+	if denominator.Sign() == 0 {
+		return nil
+	}
+
+	slots, err := callUint(ctx, c.conditionalTokens, "getOutcomeSlotCount", resolution.ConditionID)
+	if err != nil {
+		return fmt.Errorf("failed to get outcome slot count: %w", err)
+	}
+
+	for i := int64(0); i < slots.Int64(); i++ {
+		numerator, err := callUint(ctx, c.conditionalTokens, "payoutNumerators", resolution.ConditionID, big.NewInt(i))
+		if err != nil {
+			return fmt.Errorf("failed to get payout numerator %d: %w", i, err)
+		}
+		resolution.PayoutNumerators = append(resolution.PayoutNumerators, numerator)
+	}
+
+	resolution.State = ResolutionResolved
+	return nil
+}
@@ -0,0 +1,189 @@
+package ctf
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestResolutionState(t *testing.T) {
+	tests := []struct {
+		oracle OracleState
+		want   ResolutionState
+	}{
+		{OracleStateInvalid, ResolutionUnknown},
+		{OracleStateRequested, ResolutionRequested},
+		{OracleStateProposed, ResolutionProposed},
+		{OracleStateExpired, ResolutionSettled},
+		{OracleStateDisputed, ResolutionDisputed},
+		{OracleStateResolved, ResolutionSettled},
+		{OracleStateSettled, ResolutionSettled},
+		{OracleState(9), ResolutionUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := resolutionState(tt.oracle); got != tt.want {
+			t.Errorf("resolutionState(%s) = %s, want %s", tt.oracle, got, tt.want)
+		}
+	}
+}
+
+var (
+	testQuestionID = common.HexToHash("0x5ca1ab1e")
+	testOracle     = common.HexToAddress("0xee3Afe347D5C74317041E2618C49534dAf887c24")
+)
+
+// resolutionChain is the on-chain state read by GetResolution
+type resolutionChain struct {
+	payouts          []int64
+	requestTimestamp int64
+	paused           bool
+	reset            bool
+	oracle           OracleState
+}
+
+func (c resolutionChain) deploy(backend *testBackend) {
+	backend.deploy(testContracts.ConditionalTokens, conditionalTokensABI, func(method string, args []any) ([]any, error) {
+		var denominator int64
+		for _, p := range c.payouts {
+			denominator += p
+		}
+		switch method {
+		case "payoutDenominator":
+			return []any{big.NewInt(denominator)}, nil
+		case "getOutcomeSlotCount":
+			return []any{big.NewInt(int64(len(c.payouts)))}, nil
+		case "payoutNumerators":
+			return []any{big.NewInt(c.payouts[args[1].(*big.Int).Int64()])}, nil
+		}
+		return nil, fmt.Errorf("unexpected call %s", method)
+	})
+	backend.deploy(testContracts.UmaAdapter, umaCtfAdapterABI, func(method string, args []any) ([]any, error) {
+		switch method {
+		case "getQuestion":
+			return []any{questionData{
+				RequestTimestamp:          big.NewInt(c.requestTimestamp),
+				Reward:                    big.NewInt(5e6),
+				ProposalBond:              big.NewInt(500e6),
+				Liveness:                  big.NewInt(7200),
+				ManualResolutionTimestamp: new(big.Int),
+				Paused:                    c.paused,
+				Reset:                     c.reset,
+				AncillaryData:             []byte("q: title: Will it rain?"),
+			}}, nil
+		case "optimisticOracle":
+			return []any{testOracle}, nil
+		}
+		return nil, fmt.Errorf("unexpected call %s", method)
+	})
+	backend.deploy(testOracle, optimisticOracleABI, func(method string, args []any) ([]any, error) {
+		if args[0].(common.Address) != testContracts.UmaAdapter || args[2].(*big.Int).Int64() != c.requestTimestamp {
+			return nil, fmt.Errorf("getState of another request")
+		}
+		return []any{uint8(c.oracle)}, nil
+	})
+}
+
+func TestGetResolution(t *testing.T) {
+	tests := []struct {
+		name       string
+		chain      resolutionChain
+		state      ResolutionState
+		quotable   bool
+		redeemable bool
+		// oracleRead reports whether the oracle is queried
+		oracleRead bool
+	}{
+		{
+			name:  "no request",
+			chain: resolutionChain{oracle: OracleStateRequested},
+			state: ResolutionUnknown,
+		},
+		{
+			name:       "requested",
+			chain:      resolutionChain{requestTimestamp: 1700000000, oracle: OracleStateRequested},
+			state:      ResolutionRequested,
+			quotable:   true,
+			oracleRead: true,
+		},
+		{
+			name:       "paused",
+			chain:      resolutionChain{requestTimestamp: 1700000000, paused: true, oracle: OracleStateRequested},
+			state:      ResolutionRequested,
+			oracleRead: true,
+		},
+		{
+			name:       "proposed",
+			chain:      resolutionChain{requestTimestamp: 1700000000, oracle: OracleStateProposed},
+			state:      ResolutionProposed,
+			oracleRead: true,
+		},
+		{
+			name:       "disputed after a reset",
+			chain:      resolutionChain{requestTimestamp: 1700000000, reset: true, oracle: OracleStateDisputed},
+			state:      ResolutionDisputed,
+			oracleRead: true,
+		},
+		{
+			name:       "settled",
+			chain:      resolutionChain{requestTimestamp: 1700000000, oracle: OracleStateSettled},
+			state:      ResolutionSettled,
+			oracleRead: true,
+		},
+		{
+			name:       "resolved",
+			chain:      resolutionChain{payouts: []int64{1, 0}, requestTimestamp: 1700000000, oracle: OracleStateSettled},
+			state:      ResolutionResolved,
+			redeemable: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := newTestBackend()
+			tt.chain.deploy(backend)
+			client := newTestClient(t, backend)
+
+			resolution, err := client.GetResolution(context.Background(), testQuestionID, testConditionID, false)
+			if err != nil {
+				t.Fatalf("GetResolution failed: %v", err)
+			}
+			if resolution.State != tt.state {
+				t.Errorf("state = %s, want %s", resolution.State, tt.state)
+			}
+			if resolution.Quotable() != tt.quotable || resolution.Redeemable() != tt.redeemable {
+				t.Errorf("quotable %v, redeemable %v", resolution.Quotable(), resolution.Redeemable())
+			}
+			if resolution.Paused != tt.chain.paused || resolution.Reset != tt.chain.reset {
+				t.Errorf("paused %v, reset %v", resolution.Paused, resolution.Reset)
+			}
+			if resolution.RequestTimestamp != uint64(tt.chain.requestTimestamp) {
+				t.Errorf("request timestamp = %d, want %d", resolution.RequestTimestamp, tt.chain.requestTimestamp)
+			}
+			if fmt.Sprint(resolution.PayoutNumerators) != fmt.Sprint(tt.chain.payouts) {
+				t.Errorf("payouts = %v, want %v", resolution.PayoutNumerators, tt.chain.payouts)
+			}
+
+			var oracleRead bool
+			for _, call := range backend.called() {
+				oracleRead = oracleRead || call == testOracle.Hex()+".getState"
+			}
+			if oracleRead != tt.oracleRead {
+				t.Errorf("oracle read %v, want %v: %v", oracleRead, tt.oracleRead, backend.called())
+			}
+			if tt.oracleRead && resolution.OracleState != tt.chain.oracle {
+				t.Errorf("oracle state = %s, want %s", resolution.OracleState, tt.chain.oracle)
+			}
+		})
+	}
+}
+
+func TestGetResolutionRequiresAdapter(t *testing.T) {
+	client := newTestClient(t, newTestBackend())
+	if _, err := client.GetResolutionFromAdapter(context.Background(), common.Address{}, testQuestionID, testConditionID); err == nil {
+		t.Error("GetResolutionFromAdapter succeeded without an adapter")
+	}
+}
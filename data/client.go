@@ -9,6 +9,9 @@ import (
 	"reflect"
 	"strings"
 	"time"
+
+	"github.com/lixvyang/polymarket-sdk-go/auth"
+	"github.com/lixvyang/polymarket-sdk-go/types"
 )
 
 const (
@@ -21,13 +24,20 @@ type DataSDK struct {
 	baseURL     string
 	proxyConfig *ProxyConfig
 	httpClient  *http.Client
+	defaultUser *string
 }
 
 // NewDataSDK creates a new Data SDK instance
 func NewDataSDK(config *DataSDKConfig) *DataSDK {
 	var proxyConfig *ProxyConfig
+	var defaultUser *string
+	baseURL := DataAPIBase
 	if config != nil {
 		proxyConfig = config.Proxy
+		defaultUser = config.User
+		if config.BaseURL != "" {
+			baseURL = strings.TrimRight(config.BaseURL, "/")
+		}
 	}
 
 	// Create HTTP client with proxy if configured
@@ -63,14 +73,38 @@ func NewDataSDK(config *DataSDKConfig) *DataSDK {
 	}
 
 	client := &DataSDK{
-		baseURL:     DataAPIBase,
+		baseURL:     baseURL,
 		proxyConfig: proxyConfig,
 		httpClient:  httpClient,
+		defaultUser: defaultUser,
 	}
 
 	return client
 }
 
+// NewDataSDKForSigner creates a Data SDK whose default user is the address
+// holding the funds of signer for the signature type: the EOA itself, its
+// proxy wallet or its Safe. The User of config is ignored.
+func NewDataSDKForSigner(signer auth.Signer, chainID types.Chain, signatureType types.SignatureType, config *DataSDKConfig) (*DataSDK, error) {
+	if signer == nil {
+		return nil, fmt.Errorf("signer is required")
+	}
+
+	funder, err := auth.GetFunderAddress(signer.GetAddress(), chainID, signatureType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive funder address: %w", err)
+	}
+
+	var cfg DataSDKConfig
+	if config != nil {
+		cfg = *config
+	}
+	user := funder.Hex()
+	cfg.User = &user
+
+	return NewDataSDK(&cfg), nil
+}
+
 // GetHttpClient returns the underlying HTTP client (useful for custom requests)
 func (d *DataSDK) GetHttpClient() *http.Client {
 	return d.httpClient
@@ -251,12 +285,24 @@ func (d *DataSDK) GetHealth() (*DataHealthResponse, error) {
 	return &result, nil
 }
 
+// userOrDefault returns user, or the default user when it is not set.
+// Queries are copied before it is applied, so the caller's query is unchanged.
+func (d *DataSDK) userOrDefault(user *string) *string {
+	if user == nil {
+		return d.defaultUser
+	}
+	return user
+}
+
 // Positions API
 // GetCurrentPositions gets current positions for a user
 func (d *DataSDK) GetCurrentPositions(query *PositionsQuery) ([]Position, error) {
 	if query == nil {
 		query = &PositionsQuery{}
 	}
+	withUser := *query
+	withUser.User = d.userOrDefault(query.User)
+	query = &withUser
 
 	resp, err := d.makeRequest("GET", "/positions", query)
 	if err != nil {
@@ -271,6 +317,9 @@ func (d *DataSDK) GetClosedPositions(query *ClosedPositionsQuery) ([]ClosedPosit
 	if query == nil {
 		query = &ClosedPositionsQuery{}
 	}
+	withUser := *query
+	withUser.User = d.userOrDefault(query.User)
+	query = &withUser
 
 	resp, err := d.makeRequest("GET", "/closed-positions", query)
 	if err != nil {
@@ -301,6 +350,9 @@ func (d *DataSDK) GetUserActivity(query *UserActivityQuery) ([]Activity, error)
 	if query == nil {
 		query = &UserActivityQuery{}
 	}
+	withUser := *query
+	withUser.User = d.userOrDefault(query.User)
+	query = &withUser
 
 	resp, err := d.makeRequest("GET", "/activity", query)
 	if err != nil {
@@ -331,6 +383,9 @@ func (d *DataSDK) GetTotalValue(query *TotalValueQuery) ([]TotalValue, error) {
 	if query == nil {
 		query = &TotalValueQuery{}
 	}
+	withUser := *query
+	withUser.User = d.userOrDefault(query.User)
+	query = &withUser
 
 	resp, err := d.makeRequest("GET", "/value", query)
 	if err != nil {
@@ -345,6 +400,9 @@ func (d *DataSDK) GetTotalMarketsTraded(query *TotalMarketsTradedQuery) (*TotalM
 	if query == nil {
 		query = &TotalMarketsTradedQuery{}
 	}
+	withUser := *query
+	withUser.User = d.userOrDefault(query.User)
+	query = &withUser
 
 	resp, err := d.makeRequest("GET", "/traded", query)
 	if err != nil {
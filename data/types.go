@@ -1,5 +1,7 @@
 package data
 
+import "github.com/lixvyang/polymarket-sdk-go/types"
+
 // ProxyConfig represents HTTP/HTTPS proxy configuration
 type ProxyConfig struct {
 	Host     string  `json:"host"`
@@ -12,6 +14,11 @@ type ProxyConfig struct {
 // DataSDKConfig represents configuration for the Data SDK
 type DataSDKConfig struct {
 	Proxy *ProxyConfig `json:"proxy,omitempty"` // HTTP/HTTPS proxy configuration
+	// User is the default address for user queries that do not set one,
+	// e.g. the proxy wallet from auth.GetProxyWalletAddress
+	User *string `json:"user,omitempty"`
+	// BaseURL overrides the Data API URL (default DataAPIBase)
+	BaseURL string `json:"baseUrl,omitempty"`
 }
 
 // Position represents a user's position from the Data API
@@ -19,16 +26,16 @@ type Position struct {
 	ProxyWallet      string  `json:"proxyWallet"`
 	Asset            string  `json:"asset"`
 	ConditionID      string  `json:"conditionId"`
-	Size             float64 `json:"size"`
-	AvgPrice         float64 `json:"avgPrice"`
-	InitialValue     float64 `json:"initialValue"`
-	CurrentValue     float64 `json:"currentValue"`
-	CashPnl          float64 `json:"cashPnl"`
-	PercentPnl       float64 `json:"percentPnl"`
-	TotalBought      float64 `json:"totalBought"`
-	RealizedPnl      float64 `json:"realizedPnl"`
-	PercentRealizedPnl float64 `json:"percentRealizedPnl"`
-	CurPrice         float64 `json:"curPrice"`
+	Size             types.Decimal `json:"size"`
+	AvgPrice         types.Decimal `json:"avgPrice"`
+	InitialValue     types.Decimal `json:"initialValue"`
+	CurrentValue     types.Decimal `json:"currentValue"`
+	CashPnl          types.Decimal `json:"cashPnl"`
+	PercentPnl       types.Decimal `json:"percentPnl"`
+	TotalBought      types.Decimal `json:"totalBought"`
+	RealizedPnl      types.Decimal `json:"realizedPnl"`
+	PercentRealizedPnl types.Decimal `json:"percentRealizedPnl"`
+	CurPrice         types.Decimal `json:"curPrice"`
 	Redeemable       bool    `json:"redeemable"`
 	Mergeable        bool    `json:"mergeable"`
 	Title            string  `json:"title"`
@@ -49,10 +56,10 @@ type ClosedPosition struct {
 	ProxyWallet    string  `json:"proxyWallet"`
 	Asset          string  `json:"asset"`
 	ConditionID    string  `json:"conditionId"`
-	Size           float64 `json:"size"`
-	AvgPrice       float64 `json:"avgPrice"`
-	RealizedPnl    float64 `json:"realizedPnl"`
-	ClosedPrice    float64 `json:"closedPrice"`
+	Size           types.Decimal `json:"size"`
+	AvgPrice       types.Decimal `json:"avgPrice"`
+	RealizedPnl    types.Decimal `json:"realizedPnl"`
+	ClosedPrice    types.Decimal `json:"closedPrice"`
 	ClosedAt       string  `json:"closedAt"`
 	Title          string  `json:"title"`
 	Slug           string  `json:"slug"`
@@ -73,9 +80,9 @@ type DataTrade struct {
 	ConditionID     string  `json:"conditionId"`
 	Outcome         string  `json:"outcome"`
 	Market          string  `json:"market"`
-	Size            float64 `json:"size"`
-	Price           float64 `json:"price"`
-	Fee             *float64 `json:"fee,omitempty"`
+	Size            types.Decimal `json:"size"`
+	Price           types.Decimal `json:"price"`
+	Fee             *types.Decimal `json:"fee,omitempty"`
 	Timestamp       int64   `json:"timestamp"`
 	TransactionHash string  `json:"transactionHash"`
 	Maker           string  `json:"maker"`
@@ -99,10 +106,10 @@ type Activity struct {
 	ProxyWallet     string  `json:"proxyWallet"`
 	Timestamp       int64   `json:"timestamp"`
 	Type            string  `json:"type"` // "TRADE", "CANCEL", "FUND", "REDEEM"
-	Size            float64 `json:"size"`
-	UsdcSize        float64 `json:"usdcSize"`
-	Price           *float64 `json:"price,omitempty"`
-	Fee             *float64 `json:"fee,omitempty"`
+	Size            types.Decimal `json:"size"`
+	UsdcSize        types.Decimal `json:"usdcSize"`
+	Price           *types.Decimal `json:"price,omitempty"`
+	Fee             *types.Decimal `json:"fee,omitempty"`
 	ConditionID     string  `json:"conditionId"`
 	Outcome         string  `json:"outcome"`
 	Market          string  `json:"market"`
@@ -110,7 +117,7 @@ type Activity struct {
 	From            string  `json:"from"`
 	To              string  `json:"to"`
 	AssetID         string  `json:"assetId"`
-	Value           *float64 `json:"value,omitempty"`
+	Value           *types.Decimal `json:"value,omitempty"`
 	// Additional fields from actual API response
 	Title             string `json:"title"`
 	Slug              string `json:"slug"`
@@ -127,8 +134,8 @@ type Activity struct {
 // Holder represents a holder from the Data API
 type Holder struct {
 	Wallet string `json:"wallet"`
-	Balance string `json:"balance"`
-	Value   string `json:"value"`
+	Balance types.Decimal `json:"balance"`
+	Value   types.Decimal `json:"value"`
 }
 
 // MetaHolder represents a meta holder with token and holders list
@@ -140,7 +147,7 @@ type MetaHolder struct {
 // TotalValue represents total value response from the Data API
 type TotalValue struct {
 	User  string  `json:"user"`
-	Value float64 `json:"value"`
+	Value types.Decimal `json:"value"`
 }
 
 // TotalMarketsTraded represents total markets traded response
@@ -152,13 +159,13 @@ type TotalMarketsTraded struct {
 // OpenInterest represents open interest from the Data API
 type OpenInterest struct {
 	Market string  `json:"market"`
-	Value  float64 `json:"value"`
+	Value  types.Decimal `json:"value"`
 }
 
 // LiveVolumeMarket represents live volume for a market
 type LiveVolumeMarket struct {
 	Market string  `json:"market"`
-	Value  float64 `json:"value"`
+	Value  types.Decimal `json:"value"`
 }
 
 // LiveVolumeResponse represents live volume response
@@ -179,7 +186,7 @@ type PositionsQuery struct {
 	User           *string  `json:"user,omitempty"`
 	Market         *[]string `json:"market,omitempty"`
 	EventID        *[]string `json:"eventId,omitempty"`
-	SizeThreshold  *float64  `json:"sizeThreshold,omitempty"`
+	SizeThreshold  *types.Decimal  `json:"sizeThreshold,omitempty"`
 	Redeemable     *bool     `json:"redeemable,omitempty"`
 	Mergeable      *bool     `json:"mergeable,omitempty"`
 	Limit          *int     `json:"limit,omitempty"`
@@ -207,7 +214,7 @@ type TradesQuery struct {
 	Offset       *int     `json:"offset,omitempty"`
 	TakerOnly    *bool    `json:"takerOnly,omitempty"`
 	FilterType   *string  `json:"filterType,omitempty"`
-	FilterAmount *float64 `json:"filterAmount,omitempty"`
+	FilterAmount *types.Decimal `json:"filterAmount,omitempty"`
 	Market       *[]string `json:"market,omitempty"`
 	EventID      *[]string `json:"eventId,omitempty"`
 	User         *string  `json:"user,omitempty"`
@@ -219,7 +219,7 @@ func main() {
 		timestamp := int64(1640995200) // Example timestamp
 		nonce := uint64(0)
 
-		eip712Sig, err := auth.BuildClobEip712Signature(wallet.GetPrivateKey(), int64(types.ChainPolygon), timestamp, nonce)
+		eip712Sig, err := auth.BuildClobEip712Signature(wallet, int64(types.ChainPolygon), timestamp, nonce)
 		if err != nil {
 			log.Printf("Failed to build EIP712 signature: %v", err)
 		} else {
@@ -41,8 +41,8 @@ func main() {
 				break
 			}
 			fmt.Printf("  %d. %s\n", i+1, pos.Title)
-			fmt.Printf("     Size: %.2f, PnL: %.2f (%.2f%%)\n", pos.Size, pos.CashPnl, pos.PercentPnl)
-			fmt.Printf("     Current Price: %.4f\n", pos.CurPrice)
+			fmt.Printf("     Size: %s, PnL: %s (%s%%)\n", pos.Size, pos.CashPnl, pos.PercentPnl)
+			fmt.Printf("     Current Price: %s\n", pos.CurPrice)
 		}
 	}
 
@@ -61,9 +61,9 @@ func main() {
 			if i >= 3 { // Show first 3 activities
 				break
 			}
-			fmt.Printf("  %d. %s %.6f of %s\n", i+1, act.Type, act.Size, act.Outcome)
+			fmt.Printf("  %d. %s %s of %s\n", i+1, act.Type, act.Size, act.Outcome)
 			if act.Price != nil {
-				fmt.Printf("     Price: %.6f\n", *act.Price)
+				fmt.Printf("     Price: %s\n", *act.Price)
 			}
 		}
 	}
@@ -83,7 +83,7 @@ func main() {
 			if i >= 3 { // Show first 3 trades
 				break
 			}
-			fmt.Printf("  %d. %s %.6f at %.6f\n", i+1, trade.Side, trade.Size, trade.Price)
+			fmt.Printf("  %d. %s %s at %s\n", i+1, trade.Side, trade.Size, trade.Price)
 		}
 	}
 
@@ -95,7 +95,7 @@ func main() {
 	} else {
 		fmt.Println("✅ Portfolio Summary:")
 		if len(portfolio.TotalValue) > 0 {
-			fmt.Printf("  Total Value: %.2f\n", portfolio.TotalValue[0].Value)
+			fmt.Printf("  Total Value: %s\n", portfolio.TotalValue[0].Value)
 		}
 		fmt.Printf("  Markets Traded: %d\n", portfolio.MarketsTraded.Traded)
 		fmt.Printf("  Current Positions: %d\n", len(portfolio.CurrentPositions))
@@ -111,7 +111,7 @@ func main() {
 	} else {
 		fmt.Printf("✅ Total Value:\n")
 		for _, value := range totalValue {
-			fmt.Printf("  User: %s, Value: %.2f\n", value.User, value.Value)
+			fmt.Printf("  User: %s, Value: %s\n", value.User, value.Value)
 		}
 	}
 
@@ -43,10 +43,10 @@ func main() {
 		fmt.Printf("✅ Successfully retrieved position:\n")
 		pos := positions[0]
 		fmt.Printf("  Title: %s\n", pos.Title)
-		fmt.Printf("  Size: %.2f\n", pos.Size)
-		fmt.Printf("  Current Value: %.2f\n", pos.CurrentValue)
-		fmt.Printf("  Cash PnL: %.2f\n", pos.CashPnl)
-		fmt.Printf("  Percent PnL: %.2f%%\n", pos.PercentPnl)
+		fmt.Printf("  Size: %s\n", pos.Size)
+		fmt.Printf("  Current Value: %s\n", pos.CurrentValue)
+		fmt.Printf("  Cash PnL: %s\n", pos.CashPnl)
+		fmt.Printf("  Percent PnL: %s%%\n", pos.PercentPnl)
 		fmt.Printf("  Asset: %s\n", pos.Asset)
 		fmt.Printf("  Condition ID: %s\n", pos.ConditionID)
 	}
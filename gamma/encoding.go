@@ -0,0 +1,208 @@
+package gamma
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// StringArray is a list of strings that Gamma encodes either as a JSON array
+// or as a string holding one, e.g. outcomes and CLOB token IDs
+type StringArray []string
+
+// UnmarshalJSON decodes an array or a JSON-encoded array string
+func (a *StringArray) UnmarshalJSON(data []byte) error {
+	items, err := decodeEncodedArray(data)
+	if err != nil {
+		return err
+	}
+	if items == nil {
+		*a = nil
+		return nil
+	}
+
+	result := make(StringArray, len(items))
+	for i, item := range items {
+		// Numbers are kept in their JSON form
+		if len(item) > 0 && item[0] != '"' {
+			result[i] = string(item)
+			continue
+		}
+		if err := json.Unmarshal(item, &result[i]); err != nil {
+			return fmt.Errorf("invalid string array item %s: %w", item, err)
+		}
+	}
+	*a = result
+	return nil
+}
+
+// DecimalArray is a list of decimals that Gamma encodes either as a JSON
+// array or as a string holding one, e.g. outcome prices
+type DecimalArray []types.Decimal
+
+// UnmarshalJSON decodes an array or a JSON-encoded array string
+func (a *DecimalArray) UnmarshalJSON(data []byte) error {
+	items, err := decodeEncodedArray(data)
+	if err != nil {
+		return err
+	}
+	if items == nil {
+		*a = nil
+		return nil
+	}
+
+	result := make(DecimalArray, len(items))
+	for i, item := range items {
+		if err := result[i].UnmarshalJSON(item); err != nil {
+			return fmt.Errorf("invalid decimal array item %s: %w", item, err)
+		}
+	}
+	*a = result
+	return nil
+}
+
+// decodeEncodedArray returns the raw items of an array or of a JSON-encoded
+// array string, or nil for null and empty strings
+func decodeEncodedArray(data []byte) ([]json.RawMessage, error) {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		var encoded string
+		if err := json.Unmarshal(data, &encoded); err != nil {
+			return nil, fmt.Errorf("invalid array %s: %w", data, err)
+		}
+		if strings.TrimSpace(encoded) == "" {
+			return nil, nil
+		}
+		data = []byte(encoded)
+	}
+
+	items := []json.RawMessage{}
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("invalid array %s: %w", data, err)
+	}
+	return items, nil
+}
+
+// knownFields caches the lowercased JSON names of the fields of struct types
+var knownFields sync.Map
+
+// fieldNames returns the lowercased JSON names of the fields of a struct
+// type. They are lowercased because encoding/json matches names case
+// insensitively.
+func fieldNames(t reflect.Type) map[string]bool {
+	if names, ok := knownFields.Load(t); ok {
+		return names.(map[string]bool)
+	}
+
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[strings.ToLower(name)] = true
+	}
+
+	knownFields.Store(t, names)
+	return names
+}
+
+// unmarshalWithExtra decodes a JSON object into v, a pointer to a struct
+// without an UnmarshalJSON method, and returns the object fields that v has
+// no field for
+func unmarshalWithExtra(data []byte, v any) (map[string]json.RawMessage, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+
+	names := fieldNames(reflect.TypeOf(v).Elem())
+	var extra map[string]json.RawMessage
+	for name, value := range all {
+		if names[strings.ToLower(name)] {
+			continue
+		}
+		if extra == nil {
+			extra = make(map[string]json.RawMessage)
+		}
+		extra[name] = value
+	}
+	return extra, nil
+}
+
+// marshalWithExtra encodes v, a struct without a MarshalJSON method, adding
+// the extra fields it does not already have
+func marshalWithExtra(v any, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	for name, value := range extra {
+		if _, ok := all[name]; !ok {
+			all[name] = value
+		}
+	}
+	return json.Marshal(all)
+}
+
+// UnmarshalJSON decodes a market, keeping the fields not modelled in Extra
+func (m *Market) UnmarshalJSON(data []byte) error {
+	type market Market
+	var decoded market
+	extra, err := unmarshalWithExtra(data, &decoded)
+	if err != nil {
+		return err
+	}
+
+	*m = Market(decoded)
+	m.Extra = extra
+	return nil
+}
+
+// MarshalJSON encodes a market together with its Extra fields
+func (m Market) MarshalJSON() ([]byte, error) {
+	type market Market
+	return marshalWithExtra(market(m), m.Extra)
+}
+
+// UnmarshalJSON decodes an event, keeping the fields not modelled in Extra
+func (e *Event) UnmarshalJSON(data []byte) error {
+	type event Event
+	var decoded event
+	extra, err := unmarshalWithExtra(data, &decoded)
+	if err != nil {
+		return err
+	}
+
+	*e = Event(decoded)
+	e.Extra = extra
+	return nil
+}
+
+// MarshalJSON encodes an event together with its Extra fields
+func (e Event) MarshalJSON() ([]byte, error) {
+	type event Event
+	return marshalWithExtra(event(e), e.Extra)
+}
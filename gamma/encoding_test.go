@@ -0,0 +1,336 @@
+package gamma
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// The fixtures follow the shape of Gamma /markets and /events responses for
+// the neg risk market of Donald Trump in the 2024 presidential election. The
+// condition and token IDs are the real ones; other values are illustrative.
+
+const (
+	testConditionID = "0xdd22472e552920b8438158ea7238bfadfa4f736aa4cee91a6b86c39ead110917"
+	testYesTokenID  = "21742633143463906290569050155826241533067272736897614950488156847949938836455"
+	testNoTokenID   = "48331043336612883890938759509493159234755048973500640148014422747788308965732"
+)
+
+// newTestSDK returns a Gamma SDK serving the fixture of each path
+func newTestSDK(t *testing.T, fixtures map[string]string) *GammaSDK {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fixture, ok := fixtures[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		data, err := os.ReadFile(fixture)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+
+	sdk := NewGammaSDK(nil)
+	sdk.baseURL = server.URL
+	return sdk
+}
+
+// assertMarket checks the fields of the fixture market
+func assertMarket(t *testing.T, market Market) {
+	t.Helper()
+
+	if market.ConditionID != testConditionID {
+		t.Errorf("ConditionID = %s, want %s", market.ConditionID, testConditionID)
+	}
+	if got := []string(market.Outcomes); !reflect.DeepEqual(got, []string{"Yes", "No"}) {
+		t.Errorf("Outcomes = %q, want [Yes No]", got)
+	}
+	if got := []string(market.ClobTokenIDs); !reflect.DeepEqual(got, []string{testYesTokenID, testNoTokenID}) {
+		t.Errorf("ClobTokenIDs = %q", got)
+	}
+	if len(market.OutcomePrices) != 2 || market.OutcomePrices[0].String() != "1" || market.OutcomePrices[1].String() != "0" {
+		t.Errorf("OutcomePrices = %v, want [1 0]", market.OutcomePrices)
+	}
+	if market.UmaResolutionStatuses == nil || len(market.UmaResolutionStatuses) != 0 {
+		t.Errorf("UmaResolutionStatuses = %#v, want empty", market.UmaResolutionStatuses)
+	}
+
+	// Gamma sends volume as a string and volumeNum as a number
+	if market.Volume.String() != "1531479285.329201" || market.VolumeNum.String() != "1531479285.329201" {
+		t.Errorf("Volume = %s, VolumeNum = %s, want 1531479285.329201", market.Volume, market.VolumeNum)
+	}
+	if market.OrderPriceMinTickSize == nil || market.OrderPriceMinTickSize.String() != "0.001" {
+		t.Errorf("OrderPriceMinTickSize = %v, want 0.001", market.OrderPriceMinTickSize)
+	}
+	if market.OrderMinSize == nil || market.OrderMinSize.String() != "5" {
+		t.Errorf("OrderMinSize = %v, want 5", market.OrderMinSize)
+	}
+	if market.NegRisk == nil || !*market.NegRisk {
+		t.Errorf("NegRisk = %v, want true", market.NegRisk)
+	}
+	if market.UmaResolutionStatus == nil || *market.UmaResolutionStatus != "resolved" {
+		t.Errorf("UmaResolutionStatus = %v, want resolved", market.UmaResolutionStatus)
+	}
+
+	for _, name := range []string{"approved", "clobRewards", "clearBookOnStart", "feesEnabled", "holdingRewardsEnabled", "pagerDutyNotificationEnabled", "submitted_by", "cyom"} {
+		if _, ok := market.Extra[name]; !ok {
+			t.Errorf("Extra is missing %s", name)
+		}
+	}
+	for _, name := range []string{"conditionId", "negRisk", "outcomes", "questionID"} {
+		if _, ok := market.Extra[name]; ok {
+			t.Errorf("Extra holds the modelled field %s", name)
+		}
+	}
+}
+
+func TestGetMarkets(t *testing.T) {
+	sdk := newTestSDK(t, map[string]string{"/markets": "testdata/markets.json"})
+
+	markets, err := sdk.GetMarkets(nil)
+	if err != nil {
+		t.Fatalf("GetMarkets failed: %v", err)
+	}
+	if len(markets) != 1 {
+		t.Fatalf("got %d markets, want 1", len(markets))
+	}
+
+	market := markets[0]
+	assertMarket(t, market)
+
+	if len(market.Events) != 1 {
+		t.Fatalf("got %d events, want 1", len(market.Events))
+	}
+	event := market.Events[0]
+	if event.Slug != "presidential-election-winner-2024" || event.Volume == nil || event.Volume.String() != "3686335059.295186" {
+		t.Errorf("event = %s with volume %v", event.Slug, event.Volume)
+	}
+	if _, ok := event.Extra["requiresTranslation"]; !ok {
+		t.Error("event Extra is missing requiresTranslation")
+	}
+}
+
+func TestGetEvents(t *testing.T) {
+	sdk := newTestSDK(t, map[string]string{"/events": "testdata/events.json"})
+
+	events, err := sdk.GetEvents(nil)
+	if err != nil {
+		t.Fatalf("GetEvents failed: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+
+	event := events[0]
+	if event.NegRisk == nil || !*event.NegRisk || event.CommentCount == nil || *event.CommentCount != 137457 {
+		t.Errorf("NegRisk = %v, CommentCount = %v", event.NegRisk, event.CommentCount)
+	}
+	if len(event.Tags) != 1 || event.Tags[0].Slug != "2024-presidential-election" {
+		t.Errorf("Tags = %+v", event.Tags)
+	}
+	if len(event.Markets) != 1 {
+		t.Fatalf("got %d markets, want 1", len(event.Markets))
+	}
+	assertMarket(t, event.Markets[0])
+}
+
+// roundTrip decodes a fixture into []T and encodes it again, checking that
+// the encoding is stable across a second round trip
+func roundTrip[T any](t *testing.T, raw []byte) []byte {
+	t.Helper()
+
+	var items []T
+	if err := json.Unmarshal(raw, &items); err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := json.Marshal(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var again []T
+	if err := json.Unmarshal(encoded, &again); err != nil {
+		t.Fatal(err)
+	}
+	reencoded, err := json.Marshal(again)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(reencoded, encoded) {
+		t.Errorf("encoding changed after a second round trip:\n%s\n%s", encoded, reencoded)
+	}
+	return encoded
+}
+
+func TestExtraRoundTrip(t *testing.T) {
+	tests := []struct {
+		fixture   string
+		roundTrip func(*testing.T, []byte) []byte
+	}{
+		{"testdata/markets.json", roundTrip[Market]},
+		{"testdata/events.json", roundTrip[Event]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			raw, err := os.ReadFile(tt.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var original, encoded []map[string]json.RawMessage
+			if err := json.Unmarshal(raw, &original); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(tt.roundTrip(t, raw), &encoded); err != nil {
+				t.Fatal(err)
+			}
+
+			// Every field unknown to the model comes back unchanged
+			for _, name := range []string{"approved", "clobRewards", "feesEnabled", "requiresTranslation"} {
+				want, ok := original[0][name]
+				if !ok {
+					continue
+				}
+				if got := encoded[0][name]; !jsonEqual(t, got, want) {
+					t.Errorf("%s = %s, want %s", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+// jsonEqual compares two JSON values ignoring formatting
+func jsonEqual(t *testing.T, a, b json.RawMessage) bool {
+	t.Helper()
+
+	var compactA, compactB bytes.Buffer
+	if err := json.Compact(&compactA, a); err != nil {
+		return false
+	}
+	if err := json.Compact(&compactB, b); err != nil {
+		t.Fatal(err)
+	}
+	return bytes.Equal(compactA.Bytes(), compactB.Bytes())
+}
+
+func TestStringArray(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want StringArray
+	}{
+		{"array", `["Yes", "No"]`, StringArray{"Yes", "No"}},
+		{"encoded", `"[\"Yes\", \"No\"]"`, StringArray{"Yes", "No"}},
+		{"encoded token ids", `"[\"` + testYesTokenID + `\", \"` + testNoTokenID + `\"]"`, StringArray{testYesTokenID, testNoTokenID}},
+		{"numbers", `[1, 2.5]`, StringArray{"1", "2.5"}},
+		{"encoded empty", `"[]"`, StringArray{}},
+		{"empty string", `""`, nil},
+		{"null", `null`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got StringArray
+			if err := json.Unmarshal([]byte(tt.json), &got); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecimalArray(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want []string
+	}{
+		{"array of strings", `["0.0125", "0.9875"]`, []string{"0.0125", "0.9875"}},
+		{"array of numbers", `[0.0125, 0.9875]`, []string{"0.0125", "0.9875"}},
+		{"encoded", `"[\"0.0125\", \"0.9875\"]"`, []string{"0.0125", "0.9875"}},
+		{"encoded numbers", `"[1, 0]"`, []string{"1", "0"}},
+		{"empty string", `""`, nil},
+		{"null", `null`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got DecimalArray
+			if err := json.Unmarshal([]byte(tt.json), &got); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+
+			var strs []string
+			for _, d := range got {
+				strs = append(strs, d.String())
+			}
+			if !reflect.DeepEqual(strs, tt.want) {
+				t.Errorf("got %q, want %q", strs, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{"bad price", `{"outcomePrices": "[\"0.5\", \"abc\"]"}`, `invalid decimal array item "abc"`},
+		{"bad encoded array", `{"outcomes": "[\"Yes\", "}`, `invalid array ["Yes", `},
+		{"bad token ids", `{"clobTokenIds": 5}`, `invalid array 5`},
+		{"bad volume", `{"volume": "lots"}`, `lots`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var market Market
+			err := json.Unmarshal([]byte(tt.json), &market)
+			if err == nil {
+				t.Fatal("Unmarshal succeeded")
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error %q does not mention %q", err, tt.want)
+			}
+		})
+	}
+
+	// GetMarkets reports the operation and the bad field instead of dropping the market
+	raw, err := os.ReadFile("testdata/markets.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bad := bytes.Replace(raw, []byte(`"outcomePrices": "[\"1\", \"0\"]"`), []byte(`"outcomePrices": "[\"1\", \"x\"]"`), 1)
+	if bytes.Equal(bad, raw) {
+		t.Fatal("fixture has no outcomePrices to corrupt")
+	}
+	path := t.TempDir() + "/markets.json"
+	if err := os.WriteFile(path, bad, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	sdk := newTestSDK(t, map[string]string{"/markets": path})
+	_, err = sdk.GetMarkets(nil)
+	if err == nil {
+		t.Fatal("GetMarkets succeeded with a bad outcome price")
+	}
+	want := `failed to unmarshal Get markets response: invalid decimal array item "x"`
+	if !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("error %q, want prefix %q", err, want)
+	}
+}
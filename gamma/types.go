@@ -6,6 +6,8 @@ import (
 	"net/url"
 	"strconv"
 	"time"
+
+	"github.com/lixvyang/polymarket-sdk-go/types"
 )
 
 // ProxyConfig represents HTTP/HTTPS proxy configuration
@@ -103,73 +105,76 @@ type RelatedTagsQuery struct {
 	Ascending *bool   `json:"ascending,omitempty"`
 }
 
-// EventMarket represents a market within an event
-type EventMarket struct {
-	ID                  string     `json:"id"`
-	Question            string     `json:"question"`
-	ConditionID         string     `json:"conditionId"`
-	Slug                string     `json:"slug"`
-	ResolutionSource    *string    `json:"resolutionSource,omitempty"`
-	EndDate             *string    `json:"endDate,omitempty"`
-	Liquidity           *string    `json:"liquidity,omitempty"`
-	StartDate           *string    `json:"startDate,omitempty"`
-	Image               string     `json:"image"`
-	Icon                string     `json:"icon"`
-	Description         string     `json:"description"`
-	Outcomes            []string   `json:"outcomes"`           // Parsed from JSON string
-	OutcomePrices       []string   `json:"outcomePrices"`      // Parsed from JSON string
-	Volume              *string    `json:"volume,omitempty"`
-	Active              bool       `json:"active"`
-	Closed              bool       `json:"closed"`
-	MarketMakerAddress  *string    `json:"marketMakerAddress,omitempty"`
-	CreatedAt           string     `json:"createdAt"`
-	UpdatedAt           string     `json:"updatedAt"`
-	New                 *bool      `json:"new,omitempty"`
-	ClobTokenIDs        []string   `json:"clobTokenIds"`      // Parsed from JSON string
-}
+// EventMarket is a market within an event, which Gamma returns in the same
+// shape as the markets endpoints
+type EventMarket = Market
 
 // Event represents a collection of related markets
 type Event struct {
-	ID                     string          `json:"id"`
-	Ticker                 string          `json:"ticker"`
-	Slug                   string          `json:"slug"`
-	Title                  string          `json:"title"`
-	Description            *string         `json:"description,omitempty"`
-	ResolutionSource       *string         `json:"resolutionSource,omitempty"`
-	StartDate              *string         `json:"startDate,omitempty"`
-	CreationDate           *string         `json:"creationDate,omitempty"`
-	EndDate                *string         `json:"endDate,omitempty"`
-	Image                  string          `json:"image"`
-	Icon                   string          `json:"icon"`
-	Active                 bool            `json:"active"`
-	Closed                 bool            `json:"closed"`
-	Archived               bool            `json:"archived"`
-	New                    *bool           `json:"new,omitempty"`
-	Featured               *bool           `json:"featured,omitempty"`
-	Restricted             *bool           `json:"restricted,omitempty"`
-	Liquidity              *float64        `json:"liquidity,omitempty"`
-	Volume                 *float64        `json:"volume,omitempty"`
-	Volume24hr             *float64        `json:"volume24hr,omitempty"`
-	VolumeNum              *float64        `json:"volumeNum,omitempty"`
-	LastActiveAt           *string         `json:"lastActiveAt,omitempty"`
-	LiquidityAmm           *float64        `json:"liquidityAmm,omitempty"`
-	LiquidityNum           *float64        `json:"liquidityNum,omitempty"`
-	Markets                []EventMarket   `json:"markets"`
-	Series                 []Series        `json:"series,omitempty"`
-	Tags                   []Tag           `json:"tags,omitempty"`
-	Cyom                   *bool           `json:"cyom,omitempty"`
-	ShowAllOutcomes        *bool           `json:"showAllOutcomes,omitempty"`
-	ShowMarketImages       *bool           `json:"showMarketImages,omitempty"`
-	EnableNegRisk          *bool           `json:"enableNegRisk,omitempty"`
-	AutomaticallyActive    *bool           `json:"automaticallyActive,omitempty"`
-	SeriesSlug             *string         `json:"seriesSlug,omitempty"`
-	GmpChartMode           *string         `json:"gmpChartMode,omitempty"`
-	NegRiskAugmented       *bool           `json:"negRiskAugmented,omitempty"`
-	PendingDeployment      *bool           `json:"pendingDeployment,omitempty"`
-	Deploying              *bool           `json:"deploying,omitempty"`
-	SortBy                 *string         `json:"sortBy,omitempty"`
-	ClosedTime             *string         `json:"closedTime,omitempty"`
-	AutomaticallyResolved  *bool           `json:"automaticallyResolved,omitempty"`
+	ID                    string         `json:"id"`
+	Ticker                string         `json:"ticker"`
+	Slug                  string         `json:"slug"`
+	Title                 string         `json:"title"`
+	Description           *string        `json:"description,omitempty"`
+	ResolutionSource      *string        `json:"resolutionSource,omitempty"`
+	StartDate             *string        `json:"startDate,omitempty"`
+	CreationDate          *string        `json:"creationDate,omitempty"`
+	EndDate               *string        `json:"endDate,omitempty"`
+	Image                 string         `json:"image"`
+	Icon                  string         `json:"icon"`
+	FeaturedImage         *string        `json:"featuredImage,omitempty"`
+	Category              *string        `json:"category,omitempty"`
+	Active                bool           `json:"active"`
+	Closed                bool           `json:"closed"`
+	Archived              bool           `json:"archived"`
+	New                   *bool          `json:"new,omitempty"`
+	Featured              *bool          `json:"featured,omitempty"`
+	Restricted            *bool          `json:"restricted,omitempty"`
+	Liquidity             *types.Decimal `json:"liquidity,omitempty"`
+	LiquidityAmm          *types.Decimal `json:"liquidityAmm,omitempty"`
+	LiquidityClob         *types.Decimal `json:"liquidityClob,omitempty"`
+	LiquidityNum          *types.Decimal `json:"liquidityNum,omitempty"`
+	Volume                *types.Decimal `json:"volume,omitempty"`
+	VolumeNum             *types.Decimal `json:"volumeNum,omitempty"`
+	Volume24hr            *types.Decimal `json:"volume24hr,omitempty"`
+	Volume1wk             *types.Decimal `json:"volume1wk,omitempty"`
+	Volume1mo             *types.Decimal `json:"volume1mo,omitempty"`
+	Volume1yr             *types.Decimal `json:"volume1yr,omitempty"`
+	OpenInterest          *types.Decimal `json:"openInterest,omitempty"`
+	Competitive           *types.Decimal `json:"competitive,omitempty"`
+	CommentsEnabled       *bool          `json:"commentsEnabled,omitempty"`
+	CommentCount          *int           `json:"commentCount,omitempty"`
+	LastActiveAt          *string        `json:"lastActiveAt,omitempty"`
+	PublishedAt           *string        `json:"published_at,omitempty"`
+	CreatedAt             *string        `json:"createdAt,omitempty"`
+	UpdatedAt             *string        `json:"updatedAt,omitempty"`
+	Markets               []EventMarket  `json:"markets"`
+	Series                []Series       `json:"series,omitempty"`
+	Tags                  []Tag          `json:"tags,omitempty"`
+	Cyom                  *bool          `json:"cyom,omitempty"`
+	ShowAllOutcomes       *bool          `json:"showAllOutcomes,omitempty"`
+	ShowMarketImages      *bool          `json:"showMarketImages,omitempty"`
+	EnableOrderBook       *bool          `json:"enableOrderBook,omitempty"`
+	EnableNegRisk         *bool          `json:"enableNegRisk,omitempty"`
+	NegRisk               *bool          `json:"negRisk,omitempty"`
+	NegRiskMarketID       *string        `json:"negRiskMarketID,omitempty"`
+	NegRiskFeeBps         *int           `json:"negRiskFeeBps,omitempty"`
+	NegRiskAugmented      *bool          `json:"negRiskAugmented,omitempty"`
+	AutomaticallyActive   *bool          `json:"automaticallyActive,omitempty"`
+	AutomaticallyResolved *bool          `json:"automaticallyResolved,omitempty"`
+	SeriesSlug            *string        `json:"seriesSlug,omitempty"`
+	GmpChartMode          *string        `json:"gmpChartMode,omitempty"`
+	PendingDeployment     *bool          `json:"pendingDeployment,omitempty"`
+	Deploying             *bool          `json:"deploying,omitempty"`
+	SortBy                *string        `json:"sortBy,omitempty"`
+	ClosedTime            *string        `json:"closedTime,omitempty"`
+	StartTime             *string        `json:"startTime,omitempty"`
+	EventDate             *string        `json:"eventDate,omitempty"`
+	Live                  *bool          `json:"live,omitempty"`
+	Ended                 *bool          `json:"ended,omitempty"`
+
+	// Extra holds the fields returned by Gamma that are not modelled above
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // UpdatedEventQuery represents query parameters for events
@@ -185,10 +190,10 @@ type UpdatedEventQuery struct {
 	Featured   *bool     `json:"featured,omitempty"`
 	New        *bool     `json:"new,omitempty"`
 	Restricted *bool     `json:"restricted,omitempty"`
-	MinVolume  *float64  `json:"minVolume,omitempty"`
-	MaxVolume  *float64  `json:"maxVolume,omitempty"`
-	MinLiquidity *float64 `json:"minLiquidity,omitempty"`
-	MaxLiquidity *float64 `json:"maxLiquidity,omitempty"`
+	MinVolume  *types.Decimal  `json:"minVolume,omitempty"`
+	MaxVolume  *types.Decimal  `json:"maxVolume,omitempty"`
+	MinLiquidity *types.Decimal `json:"minLiquidity,omitempty"`
+	MaxLiquidity *types.Decimal `json:"maxLiquidity,omitempty"`
 	Series     *string   `json:"series,omitempty"`
 	Tag        *string   `json:"tag,omitempty"`
 	StartDate  *string   `json:"startDate,omitempty"`
@@ -221,29 +226,111 @@ type EventByIdQuery struct {
 
 // Market represents a trading market
 type Market struct {
-	ID               string     `json:"id"`
-	Question         string     `json:"question"`
-	ConditionID      string     `json:"conditionId"`
-	Slug             string     `json:"slug"`
-	Liquidity        *string    `json:"liquidity,omitempty"`
-	StartDate        *string    `json:"startDate,omitempty"`
-	Image            string     `json:"image"`
-	Icon             string     `json:"icon"`
-	Description      string     `json:"description"`
-	Active           bool       `json:"active"`
-	Volume           string     `json:"volume"`
-	Outcomes         []string   `json:"outcomes"`         // Parsed from JSON string
-	OutcomePrices    []string   `json:"outcomePrices"`    // Parsed from JSON string
-	Closed           bool       `json:"closed"`
-	New              *bool      `json:"new,omitempty"`
-	QuestionID       *string    `json:"questionId,omitempty"`
-	VolumeNum        float64    `json:"volumeNum"`
-	LiquidityNum     *float64   `json:"liquidityNum,omitempty"`
-	StartDateIso     *string    `json:"startDateIso,omitempty"`
-	HasReviewedDates *bool      `json:"hasReviewedDates,omitempty"`
-	ClobTokenIDs     []string   `json:"clobTokenIds"`     // Parsed from JSON string
-	EndDate          *string    `json:"endDate,omitempty"`
-	LastActiveAt     *string    `json:"lastActiveAt,omitempty"`
+	ID                  string       `json:"id"`
+	Question            string       `json:"question"`
+	ConditionID         string       `json:"conditionId"`
+	QuestionID          *string      `json:"questionID,omitempty"`
+	Slug                string       `json:"slug"`
+	GroupItemTitle      *string      `json:"groupItemTitle,omitempty"`
+	GroupItemThreshold  *string      `json:"groupItemThreshold,omitempty"`
+	Category            *string      `json:"category,omitempty"`
+	MarketType          *string      `json:"marketType,omitempty"`
+	ResolutionSource    *string      `json:"resolutionSource,omitempty"`
+	Image               string       `json:"image"`
+	Icon                string       `json:"icon"`
+	Description         string       `json:"description"`
+	Outcomes            StringArray  `json:"outcomes"`
+	OutcomePrices       DecimalArray `json:"outcomePrices"`
+	ClobTokenIDs        StringArray  `json:"clobTokenIds"`
+	MarketMakerAddress  *string      `json:"marketMakerAddress,omitempty"`
+	Active              bool         `json:"active"`
+	Closed              bool         `json:"closed"`
+	Archived            *bool        `json:"archived,omitempty"`
+	New                 *bool        `json:"new,omitempty"`
+	Featured            *bool        `json:"featured,omitempty"`
+	Restricted          *bool        `json:"restricted,omitempty"`
+	Ready               *bool        `json:"ready,omitempty"`
+	Funded              *bool        `json:"funded,omitempty"`
+	AutomaticallyActive *bool        `json:"automaticallyActive,omitempty"`
+	ManualActivation    *bool        `json:"manualActivation,omitempty"`
+	PendingDeployment   *bool        `json:"pendingDeployment,omitempty"`
+	Deploying           *bool        `json:"deploying,omitempty"`
+	CommentsEnabled     *bool        `json:"commentsEnabled,omitempty"`
+	HasReviewedDates    *bool        `json:"hasReviewedDates,omitempty"`
+	StartDate           *string      `json:"startDate,omitempty"`
+	StartDateIso        *string      `json:"startDateIso,omitempty"`
+	EndDate             *string      `json:"endDate,omitempty"`
+	EndDateIso          *string      `json:"endDateIso,omitempty"`
+	ClosedTime          *string      `json:"closedTime,omitempty"`
+	GameStartTime       *string      `json:"gameStartTime,omitempty"`
+	EventStartTime      *string      `json:"eventStartTime,omitempty"`
+	SecondsDelay        *int         `json:"secondsDelay,omitempty"`
+	CreatedAt           *string      `json:"createdAt,omitempty"`
+	UpdatedAt           *string      `json:"updatedAt,omitempty"`
+	LastActiveAt        *string      `json:"lastActiveAt,omitempty"`
+
+	// Trading
+	EnableOrderBook          *bool          `json:"enableOrderBook,omitempty"`
+	AcceptingOrders          *bool          `json:"acceptingOrders,omitempty"`
+	AcceptingOrdersTimestamp *string        `json:"acceptingOrdersTimestamp,omitempty"`
+	OrderPriceMinTickSize    *types.Decimal `json:"orderPriceMinTickSize,omitempty"`
+	OrderMinSize             *types.Decimal `json:"orderMinSize,omitempty"`
+	MakerBaseFee             *int           `json:"makerBaseFee,omitempty"`
+	TakerBaseFee             *int           `json:"takerBaseFee,omitempty"`
+	NegRisk                  *bool          `json:"negRisk,omitempty"`
+	NegRiskOther             *bool          `json:"negRiskOther,omitempty"`
+	NegRiskMarketID          *string        `json:"negRiskMarketID,omitempty"`
+	NegRiskRequestID         *string        `json:"negRiskRequestID,omitempty"`
+	RfqEnabled               *bool          `json:"rfqEnabled,omitempty"`
+	RewardsMinSize           *types.Decimal `json:"rewardsMinSize,omitempty"`
+	RewardsMaxSpread         *types.Decimal `json:"rewardsMaxSpread,omitempty"`
+
+	// Prices
+	BestBid             *types.Decimal `json:"bestBid,omitempty"`
+	BestAsk             *types.Decimal `json:"bestAsk,omitempty"`
+	Spread              *types.Decimal `json:"spread,omitempty"`
+	LastTradePrice      *types.Decimal `json:"lastTradePrice,omitempty"`
+	OneHourPriceChange  *types.Decimal `json:"oneHourPriceChange,omitempty"`
+	OneDayPriceChange   *types.Decimal `json:"oneDayPriceChange,omitempty"`
+	OneWeekPriceChange  *types.Decimal `json:"oneWeekPriceChange,omitempty"`
+	OneMonthPriceChange *types.Decimal `json:"oneMonthPriceChange,omitempty"`
+	OneYearPriceChange  *types.Decimal `json:"oneYearPriceChange,omitempty"`
+
+	// Volume and liquidity
+	Volume         types.Decimal  `json:"volume"`
+	VolumeNum      types.Decimal  `json:"volumeNum"`
+	VolumeAmm      *types.Decimal `json:"volumeAmm,omitempty"`
+	VolumeClob     *types.Decimal `json:"volumeClob,omitempty"`
+	Volume24hr     *types.Decimal `json:"volume24hr,omitempty"`
+	Volume24hrClob *types.Decimal `json:"volume24hrClob,omitempty"`
+	Volume1wk      *types.Decimal `json:"volume1wk,omitempty"`
+	Volume1wkClob  *types.Decimal `json:"volume1wkClob,omitempty"`
+	Volume1mo      *types.Decimal `json:"volume1mo,omitempty"`
+	Volume1moClob  *types.Decimal `json:"volume1moClob,omitempty"`
+	Volume1yr      *types.Decimal `json:"volume1yr,omitempty"`
+	Volume1yrClob  *types.Decimal `json:"volume1yrClob,omitempty"`
+	Liquidity      *types.Decimal `json:"liquidity,omitempty"`
+	LiquidityNum   *types.Decimal `json:"liquidityNum,omitempty"`
+	LiquidityAmm   *types.Decimal `json:"liquidityAmm,omitempty"`
+	LiquidityClob  *types.Decimal `json:"liquidityClob,omitempty"`
+	Competitive    *types.Decimal `json:"competitive,omitempty"`
+
+	// Resolution
+	ResolvedBy            *string        `json:"resolvedBy,omitempty"`
+	UmaResolutionStatus   *string        `json:"umaResolutionStatus,omitempty"`
+	UmaResolutionStatuses StringArray    `json:"umaResolutionStatuses,omitempty"`
+	UmaEndDate            *string        `json:"umaEndDate,omitempty"`
+	UmaBond               *types.Decimal `json:"umaBond,omitempty"`
+	UmaReward             *types.Decimal `json:"umaReward,omitempty"`
+	CustomLiveness        *int           `json:"customLiveness,omitempty"`
+	AutomaticallyResolved *bool          `json:"automaticallyResolved,omitempty"`
+
+	// Events are the events the market belongs to (only on market endpoints)
+	Events []Event `json:"events,omitempty"`
+	Tags   []Tag   `json:"tags,omitempty"`
+
+	// Extra holds the fields returned by Gamma that are not modelled above
+	Extra map[string]json.RawMessage `json:"-"`
 }
 
 // UpdatedMarketQuery represents query parameters for markets
@@ -261,14 +348,16 @@ type UpdatedMarketQuery struct {
 	Series        *string  `json:"series,omitempty"`
 	SeriesSlug    *string  `json:"seriesSlug,omitempty"`
 	Tag           *string  `json:"tag,omitempty"`
-	MinVolume     *float64 `json:"minVolume,omitempty"`
-	MaxVolume     *float64 `json:"maxVolume,omitempty"`
-	MinLiquidity  *float64 `json:"minLiquidity,omitempty"`
-	MaxLiquidity  *float64 `json:"maxLiquidity,omitempty"`
+	MinVolume     *types.Decimal `json:"minVolume,omitempty"`
+	MaxVolume     *types.Decimal `json:"maxVolume,omitempty"`
+	MinLiquidity  *types.Decimal `json:"minLiquidity,omitempty"`
+	MaxLiquidity  *types.Decimal `json:"maxLiquidity,omitempty"`
 	StartDate     *string  `json:"startDate,omitempty"`
 	EndDate       *string  `json:"endDate,omitempty"`
 	QuestionID    *string  `json:"questionId,omitempty"`
 	ConditionID   *string  `json:"conditionId,omitempty"`
+	ConditionIDs  []string `json:"condition_ids,omitempty"`
+	ClobTokenIDs  []string `json:"clob_token_ids,omitempty"`
 }
 
 // MarketByIdQuery represents query parameters for getting market by ID
@@ -290,13 +379,13 @@ type Series struct {
 	Active        bool       `json:"active"`
 	Closed        bool       `json:"closed"`
 	Archived      bool       `json:"archived"`
-	Volume        *float64   `json:"volume,omitempty"`
-	Liquidity     *float64   `json:"liquidity,omitempty"`
+	Volume        *types.Decimal   `json:"volume,omitempty"`
+	Liquidity     *types.Decimal   `json:"liquidity,omitempty"`
 	StartDate     *string    `json:"startDate,omitempty"`
 	CreatedAt     string     `json:"createdAt"`
 	UpdatedAt     string     `json:"updatedAt"`
-	Competitive   *float64   `json:"competitive,omitempty"`
-	Volume24hr    *float64   `json:"volume24hr,omitempty"`
+	Competitive   *types.Decimal   `json:"competitive,omitempty"`
+	Volume24hr    *types.Decimal   `json:"volume24hr,omitempty"`
 	PythTokenID   *string    `json:"pythTokenId,omitempty"`
 	LastActiveAt  *string    `json:"lastActiveAt,omitempty"`
 	SeriesTypeMap *string    `json:"seriesTypeMap,omitempty"`
@@ -312,8 +401,8 @@ type SeriesQuery struct {
 	Active    *bool   `json:"active,omitempty"`
 	Closed    *bool   `json:"closed,omitempty"`
 	Archived  *bool   `json:"archived,omitempty"`
-	MinVolume *float64 `json:"minVolume,omitempty"`
-	MaxVolume *float64 `json:"maxVolume,omitempty"`
+	MinVolume *types.Decimal `json:"minVolume,omitempty"`
+	MaxVolume *types.Decimal `json:"maxVolume,omitempty"`
 	StartDate *string `json:"startDate,omitempty"`
 	EndDate   *string `json:"endDate,omitempty"`
 }
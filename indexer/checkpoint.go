@@ -0,0 +1,119 @@
+package indexer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/lixvyang/polymarket-sdk-go/internal/atomicfile"
+)
+
+// CheckpointStore persists the last indexed block per indexer name
+type CheckpointStore interface {
+	// Load returns the last indexed block, or false if there is none
+	Load(name string) (uint64, bool, error)
+	Save(name string, block uint64) error
+}
+
+// MemoryCheckpointStore keeps checkpoints in memory
+type MemoryCheckpointStore struct {
+	mu     sync.RWMutex
+	blocks map[string]uint64
+}
+
+// NewMemoryCheckpointStore creates an empty in-memory checkpoint store
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{blocks: make(map[string]uint64)}
+}
+
+// Load returns the last indexed block, or false if there is none
+func (s *MemoryCheckpointStore) Load(name string) (uint64, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	block, ok := s.blocks[name]
+	return block, ok, nil
+}
+
+// Save stores the last indexed block
+func (s *MemoryCheckpointStore) Save(name string, block uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.blocks[name] = block
+	return nil
+}
+
+// FileCheckpointStore keeps checkpoints in a JSON file keyed by indexer name
+type FileCheckpointStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileCheckpointStore creates a checkpoint store backed by the given file,
+// which is created on first save
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+// Load returns the last indexed block, or false if there is none
+func (s *FileCheckpointStore) Load(name string) (uint64, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.read()
+	if err != nil {
+		return 0, false, err
+	}
+
+	block, ok := all[name]
+	return block, ok, nil
+}
+
+// Save stores the last indexed block
+func (s *FileCheckpointStore) Save(name string, block uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	all[name] = block
+	return s.write(all)
+}
+
+func (s *FileCheckpointStore) read() (map[string]uint64, error) {
+	all := make(map[string]uint64)
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("failed to decode checkpoint file: %w", err)
+	}
+
+	return all, nil
+}
+
+// write saves all checkpoints
+func (s *FileCheckpointStore) write(all map[string]uint64) error {
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode checkpoints: %w", err)
+	}
+
+	if err := atomicfile.WriteFile(s.path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write checkpoint file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,254 @@
+package indexer
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/lixvyang/polymarket-sdk-go/ctf"
+)
+
+var (
+	exchangeABI          = ctf.MustParseABI(ctf.CTFExchangeABI)
+	conditionalTokensABI = ctf.MustParseABI(ctf.ConditionalTokensABI)
+)
+
+// events are the decoded events keyed by signature topic
+var events = map[common.Hash]abi.Event{}
+
+func init() {
+	for _, name := range []string{"OrderFilled", "OrdersMatched", "OrderCancelled"} {
+		events[exchangeABI.Events[name].ID] = exchangeABI.Events[name]
+	}
+	for _, name := range []string{"PositionSplit", "PositionsMerge", "PayoutRedemption"} {
+		events[conditionalTokensABI.Events[name].ID] = conditionalTokensABI.Events[name]
+	}
+}
+
+// LogMeta locates an event on chain
+type LogMeta struct {
+	Contract    common.Address
+	BlockNumber uint64
+	BlockHash   common.Hash
+	TxHash      common.Hash
+	TxIndex     uint
+	LogIndex    uint
+}
+
+// OrderFilled is emitted by an exchange for each order filled in a match
+type OrderFilled struct {
+	LogMeta
+	OrderHash         common.Hash
+	Maker             common.Address
+	Taker             common.Address
+	MakerAssetID      *big.Int
+	TakerAssetID      *big.Int
+	MakerAmountFilled *big.Int
+	TakerAmountFilled *big.Int
+	Fee               *big.Int
+}
+
+// OrdersMatched is emitted by an exchange once per match, for the taker order
+type OrdersMatched struct {
+	LogMeta
+	TakerOrderHash    common.Hash
+	TakerOrderMaker   common.Address
+	MakerAssetID      *big.Int
+	TakerAssetID      *big.Int
+	MakerAmountFilled *big.Int
+	TakerAmountFilled *big.Int
+}
+
+// OrderCancelled is emitted by an exchange when an order is cancelled on chain
+type OrderCancelled struct {
+	LogMeta
+	OrderHash common.Hash
+}
+
+// PositionSplit is emitted by ConditionalTokens when collateral or a position
+// is split into outcome positions
+type PositionSplit struct {
+	LogMeta
+	Stakeholder        common.Address
+	CollateralToken    common.Address
+	ParentCollectionID common.Hash
+	ConditionID        common.Hash
+	Partition          []*big.Int
+	Amount             *big.Int
+}
+
+// PositionsMerge is emitted by ConditionalTokens when outcome positions are
+// merged back
+type PositionsMerge struct {
+	LogMeta
+	Stakeholder        common.Address
+	CollateralToken    common.Address
+	ParentCollectionID common.Hash
+	ConditionID        common.Hash
+	Partition          []*big.Int
+	Amount             *big.Int
+}
+
+// PayoutRedemption is emitted by ConditionalTokens when resolved positions
+// are redeemed
+type PayoutRedemption struct {
+	LogMeta
+	Redeemer           common.Address
+	CollateralToken    common.Address
+	ParentCollectionID common.Hash
+	ConditionID        common.Hash
+	IndexSets          []*big.Int
+	Payout             *big.Int
+}
+
+// DecodeLog decodes an exchange or ConditionalTokens log into one of
+// *OrderFilled, *OrdersMatched, *OrderCancelled, *PositionSplit,
+// *PositionsMerge or *PayoutRedemption. It returns nil for other events.
+func DecodeLog(log ethtypes.Log) (any, error) {
+	if len(log.Topics) == 0 {
+		return nil, nil
+	}
+	event, ok := events[log.Topics[0]]
+	if !ok {
+		return nil, nil
+	}
+
+	args := make(map[string]any)
+	if len(log.Data) > 0 {
+		if err := event.Inputs.NonIndexed().UnpackIntoMap(args, log.Data); err != nil {
+			return nil, fmt.Errorf("failed to decode %s data: %w", event.Name, err)
+		}
+	}
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if err := abi.ParseTopicsIntoMap(args, indexed, log.Topics[1:]); err != nil {
+		return nil, fmt.Errorf("failed to decode %s topics: %w", event.Name, err)
+	}
+
+	a := eventArgs{name: event.Name, args: args}
+	meta := LogMeta{
+		Contract:    log.Address,
+		BlockNumber: log.BlockNumber,
+		BlockHash:   log.BlockHash,
+		TxHash:      log.TxHash,
+		TxIndex:     log.TxIndex,
+		LogIndex:    log.Index,
+	}
+
+	var decoded any
+	switch event.Name {
+	case "OrderFilled":
+		decoded = &OrderFilled{
+			LogMeta:           meta,
+			OrderHash:         a.hash("orderHash"),
+			Maker:             a.address("maker"),
+			Taker:             a.address("taker"),
+			MakerAssetID:      a.uint("makerAssetId"),
+			TakerAssetID:      a.uint("takerAssetId"),
+			MakerAmountFilled: a.uint("makerAmountFilled"),
+			TakerAmountFilled: a.uint("takerAmountFilled"),
+			Fee:               a.uint("fee"),
+		}
+	case "OrdersMatched":
+		decoded = &OrdersMatched{
+			LogMeta:           meta,
+			TakerOrderHash:    a.hash("takerOrderHash"),
+			TakerOrderMaker:   a.address("takerOrderMaker"),
+			MakerAssetID:      a.uint("makerAssetId"),
+			TakerAssetID:      a.uint("takerAssetId"),
+			MakerAmountFilled: a.uint("makerAmountFilled"),
+			TakerAmountFilled: a.uint("takerAmountFilled"),
+		}
+	case "OrderCancelled":
+		decoded = &OrderCancelled{
+			LogMeta:   meta,
+			OrderHash: a.hash("orderHash"),
+		}
+	case "PositionSplit":
+		decoded = &PositionSplit{
+			LogMeta:            meta,
+			Stakeholder:        a.address("stakeholder"),
+			CollateralToken:    a.address("collateralToken"),
+			ParentCollectionID: a.hash("parentCollectionId"),
+			ConditionID:        a.hash("conditionId"),
+			Partition:          a.uints("partition"),
+			Amount:             a.uint("amount"),
+		}
+	case "PositionsMerge":
+		decoded = &PositionsMerge{
+			LogMeta:            meta,
+			Stakeholder:        a.address("stakeholder"),
+			CollateralToken:    a.address("collateralToken"),
+			ParentCollectionID: a.hash("parentCollectionId"),
+			ConditionID:        a.hash("conditionId"),
+			Partition:          a.uints("partition"),
+			Amount:             a.uint("amount"),
+		}
+	case "PayoutRedemption":
+		decoded = &PayoutRedemption{
+			LogMeta:            meta,
+			Redeemer:           a.address("redeemer"),
+			CollateralToken:    a.address("collateralToken"),
+			ParentCollectionID: a.hash("parentCollectionId"),
+			ConditionID:        a.hash("conditionId"),
+			IndexSets:          a.uints("indexSets"),
+			Payout:             a.uint("payout"),
+		}
+	}
+
+	if a.err != nil {
+		return nil, a.err
+	}
+	return decoded, nil
+}
+
+// eventArgs reads typed event arguments, keeping the first type mismatch
+type eventArgs struct {
+	name string
+	args map[string]any
+	err  error
+}
+
+func (a *eventArgs) fail(arg string, value any) {
+	if a.err == nil {
+		a.err = fmt.Errorf("unexpected %s %s type %T", a.name, arg, value)
+	}
+}
+
+func (a *eventArgs) hash(arg string) common.Hash {
+	value, ok := a.args[arg].([32]byte)
+	if !ok {
+		a.fail(arg, a.args[arg])
+	}
+	return value
+}
+
+func (a *eventArgs) address(arg string) common.Address {
+	value, ok := a.args[arg].(common.Address)
+	if !ok {
+		a.fail(arg, a.args[arg])
+	}
+	return value
+}
+
+func (a *eventArgs) uint(arg string) *big.Int {
+	value, ok := a.args[arg].(*big.Int)
+	if !ok {
+		a.fail(arg, a.args[arg])
+	}
+	return value
+}
+
+func (a *eventArgs) uints(arg string) []*big.Int {
+	value, ok := a.args[arg].([]*big.Int)
+	if !ok {
+		a.fail(arg, a.args[arg])
+	}
+	return value
+}
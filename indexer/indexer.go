@@ -0,0 +1,298 @@
+// Package indexer reads CTF Exchange and ConditionalTokens events straight
+// from chain logs, as ground truth for fills, cancellations and position
+// changes that the Data API reports with a delay.
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// Backend reads logs and the chain head, e.g. ethclient.Client or a
+// simulated backend client
+type Backend interface {
+	ethereum.LogFilterer
+	ethereum.BlockNumberReader
+}
+
+// Config configures an indexer
+type Config struct {
+	ChainID types.Chain
+	Backend Backend
+
+	// Contracts overrides the contract addresses registered for ChainID,
+	// e.g. on a local devnet
+	Contracts *types.ContractConfig
+
+	// Checkpoints stores the last indexed block under Name. Defaults to an
+	// in-memory store.
+	Checkpoints CheckpointStore
+	Name        string
+
+	// StartBlock is the first block indexed when there is no checkpoint
+	StartBlock uint64
+	// BatchSize is the number of blocks per log query (default 2000). It is
+	// halved whenever the RPC rejects a query as too large
+	BatchSize uint64
+	// Confirmations keeps the indexer this many blocks behind the head so
+	// that indexed blocks are not reorganized
+	Confirmations uint64
+	// PollInterval is the delay between syncs in Run (default 5 seconds)
+	PollInterval time.Duration
+}
+
+// Callbacks receive decoded events in chain order. An error stops the sync
+// before the checkpoint of the batch is saved, so the batch is delivered
+// again on the next sync.
+type Callbacks struct {
+	OnOrderFilled      func(event *OrderFilled) error
+	OnOrdersMatched    func(event *OrdersMatched) error
+	OnOrderCancelled   func(event *OrderCancelled) error
+	OnPositionSplit    func(event *PositionSplit) error
+	OnPositionsMerge   func(event *PositionsMerge) error
+	OnPayoutRedemption func(event *PayoutRedemption) error
+}
+
+// Indexer follows exchange and ConditionalTokens events
+type Indexer struct {
+	config    Config
+	callbacks Callbacks
+	addresses []common.Address
+	topics    []common.Hash
+}
+
+// New creates an indexer
+func New(config *Config, callbacks *Callbacks) (*Indexer, error) {
+	if config == nil || config.Backend == nil {
+		return nil, fmt.Errorf("backend is required")
+	}
+
+	contracts := config.Contracts
+	if contracts == nil {
+		var err error
+		contracts, err = types.GetContractConfig(config.ChainID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var addresses []common.Address
+	for _, address := range []common.Address{contracts.Exchange, contracts.NegRiskExchange, contracts.ConditionalTokens} {
+		if address != (common.Address{}) {
+			addresses = append(addresses, address)
+		}
+	}
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("exchange or conditional tokens addresses are required")
+	}
+
+	ix := &Indexer{config: *config, addresses: addresses}
+	if callbacks != nil {
+		ix.callbacks = *callbacks
+	}
+	if ix.config.Checkpoints == nil {
+		ix.config.Checkpoints = NewMemoryCheckpointStore()
+	}
+	if ix.config.Name == "" {
+		ix.config.Name = "polymarket"
+	}
+	if ix.config.BatchSize == 0 {
+		ix.config.BatchSize = 2000
+	}
+	if ix.config.PollInterval == 0 {
+		ix.config.PollInterval = 5 * time.Second
+	}
+	for topic := range events {
+		ix.topics = append(ix.topics, topic)
+	}
+
+	return ix, nil
+}
+
+// FilterEvents returns the decoded events of the blocks from..to, inclusive,
+// in chain order
+func (ix *Indexer) FilterEvents(ctx context.Context, from, to uint64) ([]any, error) {
+	logs, err := ix.config.Backend.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: ix.addresses,
+		Topics:    [][]common.Hash{ix.topics},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter logs of blocks %d-%d: %w", from, to, err)
+	}
+
+	sort.SliceStable(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber < logs[j].BlockNumber
+		}
+		return logs[i].Index < logs[j].Index
+	})
+
+	var decoded []any
+	for _, log := range logs {
+		if log.Removed {
+			continue
+		}
+		event, err := DecodeLog(log)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode log %d of tx %s: %w", log.Index, log.TxHash.Hex(), err)
+		}
+		if event != nil {
+			decoded = append(decoded, event)
+		}
+	}
+
+	return decoded, nil
+}
+
+// Checkpoint returns the last indexed block, or false if nothing was indexed
+func (ix *Indexer) Checkpoint() (uint64, bool, error) {
+	block, ok, err := ix.config.Checkpoints.Load(ix.config.Name)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+	return block, ok, nil
+}
+
+// Sync indexes the blocks after the checkpoint up to the confirmed head,
+// saving the checkpoint after each batch
+func (ix *Indexer) Sync(ctx context.Context) error {
+	head, err := ix.config.Backend.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get block number: %w", err)
+	}
+	if head < ix.config.Confirmations {
+		return nil
+	}
+	target := head - ix.config.Confirmations
+
+	next := ix.config.StartBlock
+	last, ok, err := ix.Checkpoint()
+	if err != nil {
+		return err
+	}
+	if ok {
+		next = last + 1
+	}
+
+	for next <= target {
+		to := min(next+ix.config.BatchSize-1, target)
+
+		decoded, err := ix.FilterEvents(ctx, next, to)
+		if err != nil {
+			if to > next && isRangeTooLarge(err) {
+				// Retry the same blocks with half the range, and keep the
+				// smaller batch for the queries after it
+				ix.config.BatchSize = max((to-next+1)/2, 1)
+				continue
+			}
+			return err
+		}
+		for _, event := range decoded {
+			if err := ix.dispatch(event); err != nil {
+				return err
+			}
+		}
+
+		if err := ix.config.Checkpoints.Save(ix.config.Name, to); err != nil {
+			return fmt.Errorf("failed to save checkpoint: %w", err)
+		}
+		next = to + 1
+	}
+
+	return nil
+}
+
+// rangeTooLargeErrors are the messages RPC providers use to reject a log
+// query that spans too many blocks or matches too many logs
+var rangeTooLargeErrors = []string{
+	"query returned more than",
+	"block range",
+	"range too large",
+	"range is too large",
+	"response size exceeded",
+	"is limited to",
+	"too many results",
+	"too many logs",
+}
+
+// isRangeTooLarge reports whether a log query failed because of its size
+func isRangeTooLarge(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, pattern := range rangeTooLargeErrors {
+		if strings.Contains(msg, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// Run syncs every PollInterval until the context is done
+func (ix *Indexer) Run(ctx context.Context) error {
+	ticker := time.NewTicker(ix.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := ix.Sync(ctx); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatch passes an event to its callback
+func (ix *Indexer) dispatch(event any) error {
+	var err error
+	var meta LogMeta
+	switch e := event.(type) {
+	case *OrderFilled:
+		meta = e.LogMeta
+		if ix.callbacks.OnOrderFilled != nil {
+			err = ix.callbacks.OnOrderFilled(e)
+		}
+	case *OrdersMatched:
+		meta = e.LogMeta
+		if ix.callbacks.OnOrdersMatched != nil {
+			err = ix.callbacks.OnOrdersMatched(e)
+		}
+	case *OrderCancelled:
+		meta = e.LogMeta
+		if ix.callbacks.OnOrderCancelled != nil {
+			err = ix.callbacks.OnOrderCancelled(e)
+		}
+	case *PositionSplit:
+		meta = e.LogMeta
+		if ix.callbacks.OnPositionSplit != nil {
+			err = ix.callbacks.OnPositionSplit(e)
+		}
+	case *PositionsMerge:
+		meta = e.LogMeta
+		if ix.callbacks.OnPositionsMerge != nil {
+			err = ix.callbacks.OnPositionsMerge(e)
+		}
+	case *PayoutRedemption:
+		meta = e.LogMeta
+		if ix.callbacks.OnPayoutRedemption != nil {
+			err = ix.callbacks.OnPayoutRedemption(e)
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to handle log %d of block %d: %w", meta.LogIndex, meta.BlockNumber, err)
+	}
+	return nil
+}
@@ -0,0 +1,576 @@
+package indexer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+	"github.com/lixvyang/polymarket-sdk-go/types"
+)
+
+// emitterCode emits one log per call from its calldata: the number of topics
+// (2 to 4) in word 0, the topics in words 1 to 4 and the log data after them
+var emitterCode = hexutil.MustDecode("0x60a03603" + "8060a0600037" + "600035" +
+	"80600414602357" + "80600314603657" + "80600214604657" + "00" +
+	"5b50608035606035604035602035846000a400" +
+	"5b50606035604035602035836000a300" +
+	"5b50604035602035826000a200")
+
+var (
+	exchangeAddress   = common.HexToAddress("0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E")
+	ctfAddress        = common.HexToAddress("0x4D97DCd97eC945f40cF65F87097ACe5EA0476045")
+	otherAddress      = common.HexToAddress("0x1234")
+	testCollateral    = common.HexToAddress("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174")
+	testConditionID   = common.HexToHash("0xc0ffee")
+	testContractsConf = &types.ContractConfig{Exchange: exchangeAddress, ConditionalTokens: ctfAddress}
+)
+
+// testChain is a simulated chain with the emitter deployed at the exchange,
+// ConditionalTokens and an unrelated address
+type testChain struct {
+	t       *testing.T
+	sim     *simulated.Backend
+	client  simulated.Client
+	key     *ecdsa.PrivateKey
+	from    common.Address
+	chainID *big.Int
+	nonce   uint64
+}
+
+func newTestChain(t *testing.T) *testChain {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	sim := simulated.NewBackend(ethtypes.GenesisAlloc{
+		from:            {Balance: new(big.Int).Mul(big.NewInt(1e18), big.NewInt(100))},
+		exchangeAddress: {Code: emitterCode},
+		ctfAddress:      {Code: emitterCode},
+		otherAddress:    {Code: emitterCode},
+	})
+	t.Cleanup(func() { sim.Close() })
+
+	client := sim.Client()
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testChain{t: t, sim: sim, client: client, key: key, from: from, chainID: chainID}
+}
+
+// emit sends a transaction making the emitter at to log an event with the
+// given arguments, in ABI input order. The log is mined on the next commit.
+func (c *testChain) emit(to common.Address, event abi.Event, args ...any) {
+	c.t.Helper()
+
+	var indexed [][]any
+	var data []any
+	for i, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, []any{args[i]})
+		} else {
+			data = append(data, args[i])
+		}
+	}
+
+	topics, err := abi.MakeTopics(indexed...)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	packed, err := event.Inputs.NonIndexed().Pack(data...)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+
+	calldata := common.BigToHash(big.NewInt(int64(len(topics) + 1))).Bytes()
+	calldata = append(calldata, event.ID.Bytes()...)
+	for i := 0; i < 3; i++ {
+		var topic common.Hash
+		if i < len(topics) {
+			topic = topics[i][0]
+		}
+		calldata = append(calldata, topic.Bytes()...)
+	}
+	calldata = append(calldata, packed...)
+
+	tx, err := ethtypes.SignNewTx(c.key, ethtypes.LatestSignerForChainID(c.chainID), &ethtypes.DynamicFeeTx{
+		ChainID:   c.chainID,
+		Nonce:     c.nonce,
+		To:        &to,
+		Gas:       200000,
+		GasTipCap: big.NewInt(1e9),
+		GasFeeCap: big.NewInt(1e11),
+		Data:      calldata,
+	})
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	if err := c.client.SendTransaction(context.Background(), tx); err != nil {
+		c.t.Fatal(err)
+	}
+	c.nonce++
+}
+
+// commit mines a block and returns its number
+func (c *testChain) commit() uint64 {
+	c.t.Helper()
+
+	c.sim.Commit()
+	head, err := c.client.BlockNumber(context.Background())
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	return head
+}
+
+func (c *testChain) cancel(orderHash common.Hash) {
+	c.emit(exchangeAddress, exchangeABI.Events["OrderCancelled"], orderHash)
+}
+
+// recorder collects the order hashes of the cancellations it receives
+type recorder struct {
+	cancelled []common.Hash
+	fail      error
+}
+
+func (r *recorder) callbacks() *Callbacks {
+	return &Callbacks{
+		OnOrderCancelled: func(event *OrderCancelled) error {
+			if r.fail != nil {
+				err := r.fail
+				r.fail = nil
+				return err
+			}
+			r.cancelled = append(r.cancelled, event.OrderHash)
+			return nil
+		},
+	}
+}
+
+func TestDecodeLog(t *testing.T) {
+	chain := newTestChain(t)
+	maker := common.HexToAddress("0xaa")
+	taker := common.HexToAddress("0xbb")
+	partition := []*big.Int{big.NewInt(1), big.NewInt(2)}
+
+	chain.emit(exchangeAddress, exchangeABI.Events["OrderFilled"],
+		common.HexToHash("0x01"), maker, taker, big.NewInt(0), big.NewInt(42), big.NewInt(500), big.NewInt(1000), big.NewInt(3))
+	chain.emit(exchangeAddress, exchangeABI.Events["OrdersMatched"],
+		common.HexToHash("0x02"), taker, big.NewInt(42), big.NewInt(0), big.NewInt(1000), big.NewInt(500))
+	chain.cancel(common.HexToHash("0x03"))
+	block := chain.commit()
+
+	chain.emit(ctfAddress, conditionalTokensABI.Events["PositionSplit"],
+		maker, testCollateral, common.Hash{}, testConditionID, partition, big.NewInt(7))
+	chain.emit(ctfAddress, conditionalTokensABI.Events["PositionsMerge"],
+		maker, testCollateral, common.Hash{}, testConditionID, partition, big.NewInt(8))
+	chain.emit(ctfAddress, conditionalTokensABI.Events["PayoutRedemption"],
+		maker, testCollateral, common.Hash{}, testConditionID, partition, big.NewInt(9))
+	head := chain.commit()
+
+	logs, err := chain.client.FilterLogs(context.Background(), ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(block),
+		ToBlock:   new(big.Int).SetUint64(head),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 6 {
+		t.Fatalf("got %d logs, want 6", len(logs))
+	}
+
+	var decoded []any
+	for _, log := range logs {
+		event, err := DecodeLog(log)
+		if err != nil {
+			t.Fatalf("DecodeLog failed: %v", err)
+		}
+		decoded = append(decoded, event)
+	}
+
+	filled := decoded[0].(*OrderFilled)
+	wantFilled := OrderFilled{
+		LogMeta:           filled.LogMeta,
+		OrderHash:         common.HexToHash("0x01"),
+		Maker:             maker,
+		Taker:             taker,
+		MakerAssetID:      big.NewInt(0),
+		TakerAssetID:      big.NewInt(42),
+		MakerAmountFilled: big.NewInt(500),
+		TakerAmountFilled: big.NewInt(1000),
+		Fee:               big.NewInt(3),
+	}
+	assertEvent(t, *filled, wantFilled)
+	if filled.Contract != exchangeAddress || filled.BlockNumber != block || filled.TxHash != logs[0].TxHash {
+		t.Errorf("OrderFilled meta = %+v", filled.LogMeta)
+	}
+
+	matched := decoded[1].(*OrdersMatched)
+	wantMatched := OrdersMatched{
+		LogMeta:           matched.LogMeta,
+		TakerOrderHash:    common.HexToHash("0x02"),
+		TakerOrderMaker:   taker,
+		MakerAssetID:      big.NewInt(42),
+		TakerAssetID:      big.NewInt(0),
+		MakerAmountFilled: big.NewInt(1000),
+		TakerAmountFilled: big.NewInt(500),
+	}
+	assertEvent(t, *matched, wantMatched)
+
+	if cancelled := decoded[2].(*OrderCancelled); cancelled.OrderHash != common.HexToHash("0x03") {
+		t.Errorf("OrderCancelled hash = %s", cancelled.OrderHash)
+	}
+
+	split := decoded[3].(*PositionSplit)
+	wantSplit := PositionSplit{
+		LogMeta:         split.LogMeta,
+		Stakeholder:     maker,
+		CollateralToken: testCollateral,
+		ConditionID:     testConditionID,
+		Partition:       partition,
+		Amount:          big.NewInt(7),
+	}
+	assertEvent(t, *split, wantSplit)
+	if split.Contract != ctfAddress || split.BlockNumber != head {
+		t.Errorf("PositionSplit meta = %+v", split.LogMeta)
+	}
+
+	merge := decoded[4].(*PositionsMerge)
+	wantMerge := PositionsMerge{
+		LogMeta:         merge.LogMeta,
+		Stakeholder:     maker,
+		CollateralToken: testCollateral,
+		ConditionID:     testConditionID,
+		Partition:       partition,
+		Amount:          big.NewInt(8),
+	}
+	assertEvent(t, *merge, wantMerge)
+
+	redemption := decoded[5].(*PayoutRedemption)
+	wantRedemption := PayoutRedemption{
+		LogMeta:         redemption.LogMeta,
+		Redeemer:        maker,
+		CollateralToken: testCollateral,
+		ConditionID:     testConditionID,
+		IndexSets:       partition,
+		Payout:          big.NewInt(9),
+	}
+	assertEvent(t, *redemption, wantRedemption)
+}
+
+// assertEvent compares events by their printed values, since equal big.Ints
+// may differ in their internal representation
+func assertEvent(t *testing.T, got, want any) {
+	t.Helper()
+	if g, w := fmt.Sprintf("%+v", got), fmt.Sprintf("%+v", want); g != w {
+		t.Errorf("got %T %s, want %s", got, g, w)
+	}
+}
+
+func TestDecodeLogIgnoresOtherEvents(t *testing.T) {
+	event, err := DecodeLog(ethtypes.Log{Topics: []common.Hash{crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))}})
+	if err != nil || event != nil {
+		t.Errorf("DecodeLog = %v, %v, want nil, nil", event, err)
+	}
+}
+
+func TestFilterEventsOrder(t *testing.T) {
+	chain := newTestChain(t)
+
+	chain.cancel(common.HexToHash("0x01"))
+	chain.emit(otherAddress, exchangeABI.Events["OrderCancelled"], common.HexToHash("0xff"))
+	chain.cancel(common.HexToHash("0x02"))
+	chain.commit()
+	chain.cancel(common.HexToHash("0x03"))
+	chain.emit(ctfAddress, conditionalTokensABI.Events["PositionSplit"],
+		chain.from, testCollateral, common.Hash{}, testConditionID, []*big.Int{big.NewInt(1), big.NewInt(2)}, big.NewInt(1))
+	chain.cancel(common.HexToHash("0x04"))
+	head := chain.commit()
+
+	ix, err := New(&Config{Backend: chain.client, Contracts: testContractsConf}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := ix.FilterEvents(context.Background(), 0, head)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The log of the unrelated contract is skipped
+	if len(decoded) != 5 {
+		t.Fatalf("got %d events, want 5", len(decoded))
+	}
+
+	var previous LogMeta
+	var hashes []common.Hash
+	for i, event := range decoded {
+		var meta LogMeta
+		switch e := event.(type) {
+		case *OrderCancelled:
+			meta = e.LogMeta
+			hashes = append(hashes, e.OrderHash)
+		case *PositionSplit:
+			meta = e.LogMeta
+		default:
+			t.Fatalf("unexpected event %T", event)
+		}
+		if i > 0 && (meta.BlockNumber < previous.BlockNumber ||
+			meta.BlockNumber == previous.BlockNumber && meta.LogIndex <= previous.LogIndex) {
+			t.Errorf("event %d at block %d log %d is not after block %d log %d",
+				i, meta.BlockNumber, meta.LogIndex, previous.BlockNumber, previous.LogIndex)
+		}
+		previous = meta
+	}
+
+	want := []common.Hash{common.HexToHash("0x01"), common.HexToHash("0x02"), common.HexToHash("0x03"), common.HexToHash("0x04")}
+	if !reflect.DeepEqual(hashes, want) {
+		t.Errorf("cancelled = %v, want %v", hashes, want)
+	}
+}
+
+func TestSyncConfirmations(t *testing.T) {
+	chain := newTestChain(t)
+
+	chain.cancel(common.HexToHash("0x01"))
+	first := chain.commit()
+	chain.cancel(common.HexToHash("0x02"))
+	chain.commit()
+
+	rec := &recorder{}
+	ix, err := New(&Config{Backend: chain.client, Contracts: testContractsConf, Confirmations: 1}, rec.callbacks())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ix.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	block, ok, err := ix.Checkpoint()
+	if err != nil || !ok || block != first {
+		t.Fatalf("checkpoint = %d, %v, %v, want %d", block, ok, err, first)
+	}
+	if want := []common.Hash{common.HexToHash("0x01")}; !reflect.DeepEqual(rec.cancelled, want) {
+		t.Fatalf("cancelled = %v, want %v", rec.cancelled, want)
+	}
+
+	// Once the second block is confirmed it is delivered
+	head := chain.commit()
+	if err := ix.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	block, _, _ = ix.Checkpoint()
+	if block != head-1 {
+		t.Errorf("checkpoint = %d, want %d", block, head-1)
+	}
+	want := []common.Hash{common.HexToHash("0x01"), common.HexToHash("0x02")}
+	if !reflect.DeepEqual(rec.cancelled, want) {
+		t.Errorf("cancelled = %v, want %v", rec.cancelled, want)
+	}
+}
+
+func TestSyncCallbackErrorKeepsCheckpoint(t *testing.T) {
+	chain := newTestChain(t)
+
+	chain.cancel(common.HexToHash("0x01"))
+	chain.commit()
+	chain.cancel(common.HexToHash("0x02"))
+	chain.commit()
+
+	failure := errors.New("handler failed")
+	rec := &recorder{}
+	ix, err := New(&Config{Backend: chain.client, Contracts: testContractsConf, BatchSize: 1}, rec.callbacks())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ix.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	chain.cancel(common.HexToHash("0x03"))
+	third := chain.commit()
+
+	// The handler fails on the third cancellation: the earlier batches stay
+	// checkpointed and the failed one is delivered again on the next sync
+	rec.fail = failure
+
+	if err := ix.Sync(context.Background()); !errors.Is(err, failure) {
+		t.Fatalf("Sync error = %v, want %v", err, failure)
+	}
+	block, _, _ := ix.Checkpoint()
+	if block != third-1 {
+		t.Fatalf("checkpoint = %d, want %d", block, third-1)
+	}
+
+	if err := ix.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	block, _, _ = ix.Checkpoint()
+	if block != third {
+		t.Errorf("checkpoint = %d, want %d", block, third)
+	}
+	want := []common.Hash{common.HexToHash("0x01"), common.HexToHash("0x02"), common.HexToHash("0x03")}
+	if !reflect.DeepEqual(rec.cancelled, want) {
+		t.Errorf("cancelled = %v, want %v", rec.cancelled, want)
+	}
+}
+
+func TestSyncResumesFromFileCheckpointStore(t *testing.T) {
+	chain := newTestChain(t)
+	path := filepath.Join(t.TempDir(), "checkpoints.json")
+
+	chain.cancel(common.HexToHash("0x01"))
+	first := chain.commit()
+
+	rec := &recorder{}
+	ix, err := New(&Config{Backend: chain.client, Contracts: testContractsConf, Checkpoints: NewFileCheckpointStore(path)}, rec.callbacks())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ix.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	chain.cancel(common.HexToHash("0x02"))
+	head := chain.commit()
+
+	// A new indexer on the same file only delivers the blocks after the
+	// checkpoint
+	resumed := &recorder{}
+	ix, err = New(&Config{Backend: chain.client, Contracts: testContractsConf, Checkpoints: NewFileCheckpointStore(path)}, resumed.callbacks())
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, ok, err := ix.Checkpoint()
+	if err != nil || !ok || block != first {
+		t.Fatalf("checkpoint = %d, %v, %v, want %d", block, ok, err, first)
+	}
+	if err := ix.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []common.Hash{common.HexToHash("0x01")}; !reflect.DeepEqual(rec.cancelled, want) {
+		t.Errorf("first indexer cancelled = %v, want %v", rec.cancelled, want)
+	}
+	if want := []common.Hash{common.HexToHash("0x02")}; !reflect.DeepEqual(resumed.cancelled, want) {
+		t.Errorf("resumed indexer cancelled = %v, want %v", resumed.cancelled, want)
+	}
+	if block, _, _ := ix.Checkpoint(); block != head {
+		t.Errorf("checkpoint = %d, want %d", block, head)
+	}
+}
+
+// limitedBackend rejects log queries spanning more than limit blocks the way
+// public RPC providers do, and records the ranges it is asked for
+type limitedBackend struct {
+	Backend
+	limit  uint64
+	err    error
+	ranges [][2]uint64
+}
+
+func (b *limitedBackend) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]ethtypes.Log, error) {
+	from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+	b.ranges = append(b.ranges, [2]uint64{from, to})
+	if b.err != nil {
+		return nil, b.err
+	}
+	if to-from+1 > b.limit {
+		return nil, fmt.Errorf("block range is too large, max %d blocks", b.limit)
+	}
+	return b.Backend.FilterLogs(ctx, q)
+}
+
+func TestSyncHalvesBatchOnRangeTooLarge(t *testing.T) {
+	chain := newTestChain(t)
+
+	var want []common.Hash
+	for i := 1; i <= 6; i++ {
+		hash := common.BigToHash(big.NewInt(int64(i)))
+		chain.cancel(hash)
+		chain.commit()
+		want = append(want, hash)
+	}
+	head, err := chain.client.BlockNumber(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backend := &limitedBackend{Backend: chain.client, limit: 3}
+	rec := &recorder{}
+	ix, err := New(&Config{Backend: backend, Contracts: testContractsConf, BatchSize: 16}, rec.callbacks())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ix.Sync(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rec.cancelled, want) {
+		t.Errorf("cancelled = %v, want %v", rec.cancelled, want)
+	}
+	if block, _, _ := ix.Checkpoint(); block != head {
+		t.Errorf("checkpoint = %d, want %d", block, head)
+	}
+
+	// The rejected range is retried from the same block with half its size,
+	// and the smaller batch is kept for the rest of the sync
+	size := (head + 1) / 2
+	if ix.config.BatchSize != size {
+		t.Errorf("batch size = %d, want %d", ix.config.BatchSize, size)
+	}
+	wantRanges := [][2]uint64{{0, head}}
+	for from := uint64(0); from <= head; from += size {
+		wantRanges = append(wantRanges, [2]uint64{from, min(from+size-1, head)})
+	}
+	if !reflect.DeepEqual(backend.ranges, wantRanges) {
+		t.Errorf("ranges = %v, want %v", backend.ranges, wantRanges)
+	}
+}
+
+func TestSyncRangeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"other error", errors.New("connection refused")},
+		{"too large for a single block", errors.New("query returned more than 10000 results")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := newTestChain(t)
+			chain.cancel(common.HexToHash("0x01"))
+			chain.commit()
+
+			backend := &limitedBackend{Backend: chain.client, err: tt.err}
+			ix, err := New(&Config{Backend: backend, Contracts: testContractsConf, BatchSize: 4}, (&recorder{}).callbacks())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := ix.Sync(context.Background()); !errors.Is(err, tt.err) {
+				t.Fatalf("Sync error = %v, want %v", err, tt.err)
+			}
+			if _, ok, _ := ix.Checkpoint(); ok {
+				t.Error("checkpoint saved after a failed query")
+			}
+		})
+	}
+}
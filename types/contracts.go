@@ -0,0 +1,87 @@
+package types
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ContractConfig holds the Polymarket contract addresses of a network
+type ContractConfig struct {
+	// Exchange is the CTF Exchange that regular market orders are signed for
+	Exchange common.Address
+	// NegRiskExchange is the exchange of neg risk markets
+	NegRiskExchange common.Address
+	// NegRiskAdapter splits, merges and redeems neg risk positions
+	NegRiskAdapter common.Address
+	// ConditionalTokens is the Gnosis CTF holding the outcome tokens
+	ConditionalTokens common.Address
+	// Collateral is the ERC20 collateral token (USDC)
+	Collateral common.Address
+	// WrappedCollateral is the collateral of neg risk positions (zero if unknown)
+	WrappedCollateral common.Address
+	// ProxyFactory deploys proxy wallets (zero if unsupported)
+	ProxyFactory common.Address
+	// SafeFactory deploys Gnosis Safes (zero if unsupported)
+	SafeFactory common.Address
+	// UmaAdapter resolves markets through the UMA Optimistic Oracle
+	UmaAdapter common.Address
+	// NegRiskUmaAdapter resolves neg risk markets through the UMA Optimistic Oracle
+	NegRiskUmaAdapter common.Address
+}
+
+// ExchangeAddress returns the exchange orders are signed for
+func (c *ContractConfig) ExchangeAddress(negRisk bool) common.Address {
+	if negRisk {
+		return c.NegRiskExchange
+	}
+	return c.Exchange
+}
+
+var (
+	contractConfigsMu sync.RWMutex
+	contractConfigs   = map[Chain]ContractConfig{
+		ChainPolygon: {
+			Exchange:          common.HexToAddress("0x4bFb41d5B3570DeFd03C39a9A4D8dE6Bd8B8982E"),
+			NegRiskExchange:   common.HexToAddress("0xC5d563A36AE78145C45a50134d48A1215220f80a"),
+			NegRiskAdapter:    common.HexToAddress("0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296"),
+			ConditionalTokens: common.HexToAddress("0x4D97DCd97eC945f40cF65F87097ACe5EA0476045"),
+			Collateral:        common.HexToAddress("0x2791Bca1f2de4661ED88A30C99A7a9449Aa84174"),
+			WrappedCollateral: common.HexToAddress("0x3A3BD7bb9528E159577F7C2e685CC81A765002E2"),
+			ProxyFactory:      common.HexToAddress("0xaB45c5A4B0c941a2F231C04C3f49182e1A254052"),
+			SafeFactory:       common.HexToAddress("0xaacFeEa03eb1561C4e67d661e40682Bd20E3541b"),
+			UmaAdapter:        common.HexToAddress("0x157Ce2d672854c848c9b79C49a8Cc6cc89176a49"),
+			NegRiskUmaAdapter: common.HexToAddress("0x2F5e3684cb1F318ec51b00Edba38d79Ac2c0aA9d"),
+		},
+		ChainAmoy: {
+			Exchange:          common.HexToAddress("0xdFE02Eb6733538f8Ea35D585af8DE5958AD99E40"),
+			NegRiskExchange:   common.HexToAddress("0xC5d563A36AE78145C45a50134d48A1215220f80a"),
+			NegRiskAdapter:    common.HexToAddress("0xd91E80cF2E7be2e162c6513ceD06f1dD0dA35296"),
+			ConditionalTokens: common.HexToAddress("0x69308FB512518e39F9b16112fA8d994F4e2Bf8bB"),
+			Collateral:        common.HexToAddress("0x9c4e1703476e875070ee25b56a58b008cfb8fa78"),
+			SafeFactory:       common.HexToAddress("0xaacFeEa03eb1561C4e67d661e40682Bd20E3541b"),
+		},
+	}
+)
+
+// GetContractConfig returns the contract addresses of a chain
+func GetContractConfig(chain Chain) (*ContractConfig, error) {
+	contractConfigsMu.RLock()
+	defer contractConfigsMu.RUnlock()
+
+	config, ok := contractConfigs[chain]
+	if !ok {
+		return nil, fmt.Errorf("no contract config for chain %d", chain)
+	}
+	return &config, nil
+}
+
+// SetContractConfig registers or replaces the contract addresses of a chain,
+// e.g. for a local devnet
+func SetContractConfig(chain Chain, config ContractConfig) {
+	contractConfigsMu.Lock()
+	defer contractConfigsMu.Unlock()
+
+	contractConfigs[chain] = config
+}
@@ -0,0 +1,441 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact fixed-point decimal number for prices, sizes and
+// amounts. The zero value is 0. Decimals are immutable: arithmetic returns
+// new values.
+//
+// JSON decoding accepts both strings ("0.52") and numbers (0.52); encoding
+// produces a string so no precision is lost.
+type Decimal struct {
+	// value is coef * 10^-scale
+	coef  *big.Int
+	scale int32
+}
+
+var (
+	bigTen  = big.NewInt(10)
+	bigZero = new(big.Int)
+)
+
+// maxParseScale bounds the scale of parsed decimals, so that inputs such as
+// "1e-2000000000" cannot make String allocate gigabytes
+const maxParseScale = 1000
+
+// NewDecimal returns coef * 10^-scale, e.g. NewDecimal(52, 2) is 0.52
+func NewDecimal(coef int64, scale int32) Decimal {
+	return NewDecimalFromBigInt(big.NewInt(coef), scale)
+}
+
+// NewDecimalFromBigInt returns coef * 10^-scale
+func NewDecimalFromBigInt(coef *big.Int, scale int32) Decimal {
+	d := Decimal{coef: new(big.Int).Set(coef), scale: scale}
+	if scale < 0 {
+		d.coef.Mul(d.coef, pow10(-scale))
+		d.scale = 0
+	}
+	return d
+}
+
+// NewDecimalFromInt returns an integer decimal
+func NewDecimalFromInt(i int64) Decimal {
+	return NewDecimal(i, 0)
+}
+
+// NewDecimalFromFloat returns the decimal with the shortest representation
+// of f, so 0.1 is exactly 0.1. NaN and infinities yield zero.
+func NewDecimalFromFloat(f float64) Decimal {
+	d, err := ParseDecimal(strconv.FormatFloat(f, 'f', -1, 64))
+	if err != nil {
+		return Decimal{}
+	}
+	return d
+}
+
+// NewDecimalFromUnits converts an integer amount in base units, e.g. the
+// 6 decimals of USDC, to a decimal
+func NewDecimalFromUnits(units *big.Int, decimals int32) Decimal {
+	return NewDecimalFromBigInt(units, decimals)
+}
+
+// ParseDecimal parses a decimal string such as "0.52", "-3", "1e-3" or ".5"
+func ParseDecimal(s string) (Decimal, error) {
+	str := strings.TrimSpace(s)
+
+	var exp int64
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(str[i+1:], 10, 32)
+		if err != nil {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+		exp = e
+		str = str[:i]
+	}
+
+	intPart, fracPart := str, ""
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		intPart, fracPart = str[:i], str[i+1:]
+	}
+
+	sign := ""
+	if len(intPart) > 0 && (intPart[0] == '-' || intPart[0] == '+') {
+		sign, intPart = intPart[:1], intPart[1:]
+	}
+
+	digits := intPart + fracPart
+	if digits == "" || strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	coef, ok := new(big.Int).SetString(sign+digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	scale := int64(len(fracPart)) - exp
+	if scale > maxParseScale || scale < -maxParseScale {
+		return Decimal{}, fmt.Errorf("decimal %q out of range", s)
+	}
+
+	return NewDecimalFromBigInt(coef, int32(scale)), nil
+}
+
+// MustDecimal parses a decimal string and panics if it is invalid, for constants
+func MustDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+func (d Decimal) coefficient() *big.Int {
+	if d.coef == nil {
+		return bigZero
+	}
+	return d.coef
+}
+
+// rescale returns the coefficient of d at a larger or equal scale
+func (d Decimal) rescale(scale int32) *big.Int {
+	coef := new(big.Int).Set(d.coefficient())
+	if scale > d.scale {
+		coef.Mul(coef, pow10(scale-d.scale))
+	}
+	return coef
+}
+
+// String returns the decimal in plain notation, keeping trailing zeros
+func (d Decimal) String() string {
+	coef := d.coefficient()
+	if d.scale == 0 {
+		return coef.String()
+	}
+
+	digits := new(big.Int).Abs(coef).String()
+	if pad := int(d.scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+
+	point := len(digits) - int(d.scale)
+	s := digits[:point] + "." + digits[point:]
+	if coef.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// Float64 returns the nearest float64
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// Sign returns -1, 0 or 1
+func (d Decimal) Sign() int {
+	return d.coefficient().Sign()
+}
+
+// IsZero reports whether d is 0
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Cmp returns -1, 0 or 1 if d is less than, equal to or greater than other
+func (d Decimal) Cmp(other Decimal) int {
+	scale := max(d.scale, other.scale)
+	return d.rescale(scale).Cmp(other.rescale(scale))
+}
+
+// Equal reports whether d and other are the same number, e.g. 0.5 and 0.50
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+// LessThan reports whether d < other
+func (d Decimal) LessThan(other Decimal) bool {
+	return d.Cmp(other) < 0
+}
+
+// GreaterThan reports whether d > other
+func (d Decimal) GreaterThan(other Decimal) bool {
+	return d.Cmp(other) > 0
+}
+
+// Add returns d + other
+func (d Decimal) Add(other Decimal) Decimal {
+	scale := max(d.scale, other.scale)
+	return Decimal{coef: d.rescale(scale).Add(d.rescale(scale), other.rescale(scale)), scale: scale}
+}
+
+// Sub returns d - other
+func (d Decimal) Sub(other Decimal) Decimal {
+	scale := max(d.scale, other.scale)
+	return Decimal{coef: d.rescale(scale).Sub(d.rescale(scale), other.rescale(scale)), scale: scale}
+}
+
+// Mul returns d * other
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.coefficient(), other.coefficient()), scale: d.scale + other.scale}
+}
+
+// Div returns d / other rounded half away from zero to the given number of
+// decimal places, which may be negative to round to tens, hundreds, etc. It
+// panics if other is zero.
+func (d Decimal) Div(other Decimal, places int32) Decimal {
+	if other.IsZero() {
+		panic("types: division by zero")
+	}
+
+	// Divide with at least one extra digit to round on:
+	// d/other = d.coef * 10^other.scale / (other.coef * 10^d.scale)
+	scale := max(places, 0) + 1
+	num := new(big.Int).Mul(d.coefficient(), pow10(other.scale+scale))
+	den := new(big.Int).Mul(other.coefficient(), pow10(d.scale))
+
+	quo := new(big.Int).Quo(num, den)
+	return Decimal{coef: quo, scale: scale}.Round(places)
+}
+
+// Neg returns -d
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.coefficient()), scale: d.scale}
+}
+
+// Abs returns |d|
+func (d Decimal) Abs() Decimal {
+	return Decimal{coef: new(big.Int).Abs(d.coefficient()), scale: d.scale}
+}
+
+// Shift moves the decimal point, returning d * 10^places
+func (d Decimal) Shift(places int32) Decimal {
+	return NewDecimalFromBigInt(d.coefficient(), d.scale-places)
+}
+
+// DecimalPlaces returns the number of decimals without trailing zeros,
+// e.g. 2 for 0.520
+func (d Decimal) DecimalPlaces() int32 {
+	coef := new(big.Int).Set(d.coefficient())
+	if coef.Sign() == 0 {
+		return 0
+	}
+
+	places := d.scale
+	rem := new(big.Int)
+	for places > 0 {
+		q, r := new(big.Int).QuoRem(coef, bigTen, rem)
+		if r.Sign() != 0 {
+			break
+		}
+		coef = q
+		places--
+	}
+	return places
+}
+
+type roundingMode int
+
+const (
+	roundHalfUp roundingMode = iota
+	roundFloor
+	roundCeil
+	roundTruncate
+)
+
+// adjustQuotient corrects a quotient truncated toward zero for the rounding mode
+func adjustQuotient(quo, rem, div *big.Int, mode roundingMode) {
+	if rem.Sign() == 0 {
+		return
+	}
+
+	switch mode {
+	case roundHalfUp:
+		twice := new(big.Int).Abs(rem)
+		twice.Lsh(twice, 1)
+		if twice.CmpAbs(div) >= 0 {
+			quo.Add(quo, big.NewInt(int64(rem.Sign()*div.Sign())))
+		}
+	case roundFloor:
+		if rem.Sign()*div.Sign() < 0 {
+			quo.Sub(quo, big.NewInt(1))
+		}
+	case roundCeil:
+		if rem.Sign()*div.Sign() > 0 {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+}
+
+func (d Decimal) round(places int32, mode roundingMode) Decimal {
+	if places >= d.scale {
+		return d
+	}
+
+	div := pow10(d.scale - places)
+	quo, rem := new(big.Int).QuoRem(d.coefficient(), div, new(big.Int))
+	adjustQuotient(quo, rem, div, mode)
+
+	// Negative places round to tens, hundreds, etc. and are normalized back
+	// to scale 0, since the scale is never negative
+	return NewDecimalFromBigInt(quo, places)
+}
+
+// Round rounds half away from zero to the given number of decimal places.
+// Negative places round to the left of the decimal point, e.g. Round(-1)
+// rounds to tens.
+func (d Decimal) Round(places int32) Decimal {
+	return d.round(places, roundHalfUp)
+}
+
+// Floor rounds toward negative infinity to the given number of decimal places
+func (d Decimal) Floor(places int32) Decimal {
+	return d.round(places, roundFloor)
+}
+
+// Ceil rounds toward positive infinity to the given number of decimal places
+func (d Decimal) Ceil(places int32) Decimal {
+	return d.round(places, roundCeil)
+}
+
+// Truncate rounds toward zero to the given number of decimal places
+func (d Decimal) Truncate(places int32) Decimal {
+	return d.round(places, roundTruncate)
+}
+
+// toTick rounds d to a whole number of ticks
+func (d Decimal) toTick(tick Decimal, mode roundingMode) Decimal {
+	if tick.Sign() <= 0 {
+		return d
+	}
+
+	scale := max(d.scale, tick.scale)
+	size := tick.rescale(scale)
+
+	quo, rem := new(big.Int).QuoRem(d.rescale(scale), size, new(big.Int))
+	adjustQuotient(quo, rem, size, mode)
+
+	return Decimal{coef: quo.Mul(quo, tick.coefficient()), scale: tick.scale}
+}
+
+// RoundToTick rounds to the nearest multiple of tick, half away from zero
+func (d Decimal) RoundToTick(tick Decimal) Decimal {
+	return d.toTick(tick, roundHalfUp)
+}
+
+// FloorToTick rounds down to a multiple of tick
+func (d Decimal) FloorToTick(tick Decimal) Decimal {
+	return d.toTick(tick, roundFloor)
+}
+
+// CeilToTick rounds up to a multiple of tick
+func (d Decimal) CeilToTick(tick Decimal) Decimal {
+	return d.toTick(tick, roundCeil)
+}
+
+// IsMultipleOf reports whether d is a whole number of ticks
+func (d Decimal) IsMultipleOf(tick Decimal) bool {
+	if tick.IsZero() {
+		return false
+	}
+	scale := max(d.scale, tick.scale)
+	return new(big.Int).Rem(d.rescale(scale), tick.rescale(scale)).Sign() == 0
+}
+
+// BigInt returns the integer part of d, truncated toward zero
+func (d Decimal) BigInt() *big.Int {
+	return new(big.Int).Set(d.Truncate(0).rescale(0))
+}
+
+// Units converts d to an integer amount in base units, e.g. 6 decimals for
+// USDC and outcome tokens, rounding half away from zero
+func (d Decimal) Units(decimals int32) *big.Int {
+	return d.Shift(decimals).Round(0).rescale(0)
+}
+
+// MarshalJSON encodes the decimal as a JSON string
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON decodes a JSON string or number. null and "" decode to zero.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*d = Decimal{}
+		return nil
+	}
+
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return fmt.Errorf("invalid decimal %s: %w", data, err)
+		}
+		if strings.TrimSpace(s) == "" {
+			*d = Decimal{}
+			return nil
+		}
+	}
+
+	parsed, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalText encodes the decimal in plain notation
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText parses a decimal string
+func (d *Decimal) UnmarshalText(text []byte) error {
+	parsed, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Decimal returns the tick size as a decimal, or zero if it is malformed
+func (t TickSize) Decimal() Decimal {
+	d, err := ParseDecimal(string(t))
+	if err != nil {
+		return Decimal{}
+	}
+	return d
+}
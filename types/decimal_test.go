@@ -0,0 +1,207 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDecimalNegativePlaces(t *testing.T) {
+	tests := []struct {
+		name string
+		got  Decimal
+		want string
+	}{
+		{"round", MustDecimal("123").Round(-1), "120"},
+		{"round half up", MustDecimal("125").Round(-1), "130"},
+		{"round negative", MustDecimal("-125").Round(-1), "-130"},
+		{"floor", MustDecimal("-121.5").Floor(-1), "-130"},
+		{"ceil", MustDecimal("121.5").Ceil(-2), "200"},
+		{"truncate", MustDecimal("987.65").Truncate(-2), "900"},
+		{"round to zero", MustDecimal("4").Round(-1), "0"},
+		{"div", MustDecimal("1000").Div(MustDecimal("3"), -1), "330"},
+		{"div half up", MustDecimal("250").Div(MustDecimal("2"), -1), "130"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.String(); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDecimalExponentLimit(t *testing.T) {
+	for _, s := range []string{"1e-2000000000", "1e2000000000", "1e-1001", "1e1001"} {
+		if _, err := ParseDecimal(s); err == nil {
+			t.Errorf("ParseDecimal(%q) succeeded, want an out of range error", s)
+		}
+	}
+
+	d, err := ParseDecimal("1.5e-998")
+	if err != nil {
+		t.Fatalf("ParseDecimal failed: %v", err)
+	}
+	if d.Shift(999).String() != "15" {
+		t.Errorf("got %s, want 15", d.Shift(999))
+	}
+}
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"0.52", "0.52"},
+		{"0.520", "0.520"},
+		{"-3", "-3"},
+		{"+3", "3"},
+		{".5", "0.5"},
+		{"5.", "5"},
+		{"0", "0"},
+		{"-0.0001", "-0.0001"},
+		{" 12.5 ", "12.5"},
+		{"1e-3", "0.001"},
+		{"1.5E2", "150"},
+		{"123456789012345678901234567890.000001", "123456789012345678901234567890.000001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			d, err := ParseDecimal(tt.in)
+			if err != nil {
+				t.Fatalf("ParseDecimal failed: %v", err)
+			}
+			if got := d.String(); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+
+			// The string form parses back to the same value and string
+			again, err := ParseDecimal(d.String())
+			if err != nil || !again.Equal(d) || again.String() != d.String() {
+				t.Errorf("round trip of %s = %s, %v", d, again, err)
+			}
+		})
+	}
+
+	for _, s := range []string{"", " ", ".", "-", "abc", "1.2.3", "1e", "1e1.5", "0x10", "1,5", "--1"} {
+		if _, err := ParseDecimal(s); err == nil {
+			t.Errorf("ParseDecimal(%q) succeeded", s)
+		}
+	}
+
+	if got := (Decimal{}).String(); got != "0" {
+		t.Errorf("zero Decimal = %s, want 0", got)
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`"0.52"`, "0.52"},
+		{`0.52`, "0.52"},
+		{`"100"`, "100"},
+		{`100`, "100"},
+		{`-1.25`, "-1.25"},
+		{`1e-6`, "0.000001"},
+		{`" 0.5 "`, "0.5"},
+		{`""`, "0"},
+		{`null`, "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			var d Decimal
+			if err := json.Unmarshal([]byte(tt.in), &d); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			if got := d.String(); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+
+			out, err := json.Marshal(d)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := `"` + tt.want + `"`; string(out) != want {
+				t.Errorf("Marshal = %s, want %s", out, want)
+			}
+		})
+	}
+
+	for _, in := range []string{`"abc"`, `true`, `{}`, `[1]`, `"1.2.3"`} {
+		var d Decimal
+		if err := json.Unmarshal([]byte(in), &d); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded with %s", in, d)
+		}
+	}
+
+	// Fields decode the same from strings and numbers
+	var summary OrderSummary
+	if err := json.Unmarshal([]byte(`{"price":0.48,"size":"30.5"}`), &summary); err != nil {
+		t.Fatal(err)
+	}
+	if summary.Price.String() != "0.48" || summary.Size.String() != "30.5" {
+		t.Errorf("summary = %s %s, want 0.48 30.5", summary.Price, summary.Size)
+	}
+}
+
+func TestDecimalRounding(t *testing.T) {
+	// Places are those of the order rounding config: prices to the tick
+	// (1 to 4 places), sizes to 2 and amounts to 3 to 6
+	tests := []struct {
+		name string
+		got  Decimal
+		want string
+	}{
+		{"round price", MustDecimal("0.555").Round(2), "0.56"},
+		{"round price down", MustDecimal("0.5549").Round(2), "0.55"},
+		{"round keeps precision", MustDecimal("0.5").Round(2), "0.5"},
+		{"floor size", MustDecimal("100.129").Floor(2), "100.12"},
+		{"ceil size", MustDecimal("100.121").Ceil(2), "100.13"},
+		{"ceil exact", MustDecimal("100.12").Ceil(2), "100.12"},
+		{"amount 3 places", MustDecimal("0.5").Mul(MustDecimal("33.33")).Round(3), "16.665"},
+		{"amount 4 places", MustDecimal("0.57").Mul(MustDecimal("12.35")).Round(4), "7.0395"},
+		{"amount 5 places", MustDecimal("0.123").Mul(MustDecimal("45.67")).Round(5), "5.61741"},
+		{"amount 6 places", MustDecimal("0.1234").Mul(MustDecimal("45.67")).Round(6), "5.635678"},
+		{"amount floor", MustDecimal("1.2345678").Floor(6), "1.234567"},
+		{"amount ceil", MustDecimal("1.2345671").Ceil(6), "1.234568"},
+		{"amount truncate", MustDecimal("1.2345679").Truncate(6), "1.234567"},
+		{"round half up", MustDecimal("1.2345675").Round(6), "1.234568"},
+		{"negative floor", MustDecimal("-0.125").Floor(2), "-0.13"},
+		{"negative ceil", MustDecimal("-0.125").Ceil(2), "-0.12"},
+		{"negative round", MustDecimal("-0.125").Round(2), "-0.13"},
+		{"negative truncate", MustDecimal("-0.129").Truncate(2), "-0.12"},
+
+		{"tick 0.1", MustDecimal("0.46").RoundToTick(TickSize01.Decimal()), "0.5"},
+		{"tick 0.01", MustDecimal("0.555").RoundToTick(TickSize001.Decimal()), "0.56"},
+		{"tick 0.001", MustDecimal("0.5554").RoundToTick(TickSize0001.Decimal()), "0.555"},
+		{"tick 0.0001", MustDecimal("0.12345").RoundToTick(TickSize00001.Decimal()), "0.1235"},
+		{"floor to tick", MustDecimal("0.559").FloorToTick(TickSize001.Decimal()), "0.55"},
+		{"ceil to tick", MustDecimal("0.551").CeilToTick(TickSize001.Decimal()), "0.56"},
+		{"tick keeps tick places", MustDecimal("0.5").RoundToTick(TickSize001.Decimal()), "0.50"},
+		{"tick 0.005", MustDecimal("0.123").FloorToTick(MustDecimal("0.005")), "0.120"},
+		{"ceil to tick 0.005", MustDecimal("0.121").CeilToTick(MustDecimal("0.005")), "0.125"},
+		{"zero tick", MustDecimal("0.123").RoundToTick(Decimal{}), "0.123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.String(); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	tick := TickSize001.Decimal()
+	for _, tt := range []struct {
+		in   string
+		want bool
+	}{{"0.55", true}, {"0.550", true}, {"0.555", false}, {"1", true}, {"0", true}} {
+		if got := MustDecimal(tt.in).IsMultipleOf(tick); got != tt.want {
+			t.Errorf("%s.IsMultipleOf(0.01) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
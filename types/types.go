@@ -1,6 +1,7 @@
 package types
 
 import (
+	"fmt"
 	"math/big"
 	"time"
 )
@@ -35,10 +36,37 @@ const (
 type SignatureType int
 
 const (
-	SignatureTypeEIP712  SignatureType = 0
-	SignatureTypeEthSign SignatureType = 2
+	// SignatureTypeEOA is used when the signing EOA is also the order maker
+	SignatureTypeEOA SignatureType = 0
+	// SignatureTypePolyProxy is used for Polymarket proxy wallets, with the proxy as maker
+	SignatureTypePolyProxy SignatureType = 1
+	// SignatureTypePolyGnosisSafe is used for Gnosis Safe wallets, with the safe as maker
+	SignatureTypePolyGnosisSafe SignatureType = 2
+
+	// Deprecated: use SignatureTypeEOA
+	SignatureTypeEIP712 = SignatureTypeEOA
+	// Deprecated: the exchange treats 2 as SignatureTypePolyGnosisSafe
+	SignatureTypeEthSign = SignatureTypePolyGnosisSafe
 )
 
+// String returns the name used by the Polymarket CLOB
+func (s SignatureType) String() string {
+	switch s {
+	case SignatureTypeEOA:
+		return "EOA"
+	case SignatureTypePolyProxy:
+		return "POLY_PROXY"
+	case SignatureTypePolyGnosisSafe:
+		return "POLY_GNOSIS_SAFE"
+	}
+	return fmt.Sprintf("SignatureType(%d)", int(s))
+}
+
+// IsValid reports whether s is a signature type supported by the exchange
+func (s SignatureType) IsValid() bool {
+	return s == SignatureTypeEOA || s == SignatureTypePolyProxy || s == SignatureTypePolyGnosisSafe
+}
+
 // ApiKeyCreds represents API key credentials
 type ApiKeyCreds struct {
 	Key        string `json:"key"`
@@ -120,8 +148,8 @@ type NewOrder struct {
 // UserOrder represents a simplified user order
 type UserOrder struct {
 	TokenID    string  `json:"tokenID"`
-	Price      float64 `json:"price"`
-	Size       float64 `json:"size"`
+	Price      Decimal `json:"price"`
+	Size       Decimal `json:"size"`
 	Side       Side    `json:"side"`
 	FeeRateBps *int    `json:"feeRateBps,omitempty"`
 	Nonce      *int    `json:"nonce,omitempty"`
@@ -132,8 +160,8 @@ type UserOrder struct {
 // UserMarketOrder represents a simplified market order
 type UserMarketOrder struct {
 	TokenID    string     `json:"tokenID"`
-	Price      *float64   `json:"price,omitempty"`
-	Amount     float64    `json:"amount"`
+	Price      *Decimal   `json:"price,omitempty"`
+	Amount     Decimal    `json:"amount"`
 	Side       Side       `json:"side"`
 	FeeRateBps *int       `json:"feeRateBps,omitempty"`
 	Nonce      *int       `json:"nonce,omitempty"`
@@ -163,8 +191,8 @@ type OrderResponse struct {
 	OrderID            string   `json:"orderID"`
 	TransactionsHashes []string `json:"transactionsHashes"`
 	Status             string   `json:"status"`
-	TakingAmount       string   `json:"takingAmount"`
-	MakingAmount       string   `json:"makingAmount"`
+	TakingAmount       Decimal  `json:"takingAmount"`
+	MakingAmount       Decimal  `json:"makingAmount"`
 }
 
 // OpenOrder represents an open order
@@ -176,9 +204,9 @@ type OpenOrder struct {
 	Market          string   `json:"market"`
 	AssetID         string   `json:"asset_id"`
 	Side            string   `json:"side"`
-	OriginalSize    string   `json:"original_size"`
-	SizeMatched     string   `json:"size_matched"`
-	Price           string   `json:"price"`
+	OriginalSize    Decimal  `json:"original_size"`
+	SizeMatched     Decimal  `json:"size_matched"`
+	Price           Decimal  `json:"price"`
 	AssociateTrades []string `json:"associate_trades"`
 	Outcome         string   `json:"outcome"`
 	CreatedAt       int64    `json:"created_at"`
@@ -213,15 +241,15 @@ type OpenOrderParams struct {
 
 // MakerOrder represents a maker order
 type MakerOrder struct {
-	OrderID       string `json:"order_id"`
-	Owner         string `json:"owner"`
-	MakerAddress  string `json:"maker_address"`
-	MatchedAmount string `json:"matched_amount"`
-	Price         string `json:"price"`
-	FeeRateBps    string `json:"fee_rate_bps"`
-	AssetID       string `json:"asset_id"`
-	Outcome       string `json:"outcome"`
-	Side          Side   `json:"side"`
+	OrderID       string  `json:"order_id"`
+	Owner         string  `json:"owner"`
+	MakerAddress  string  `json:"maker_address"`
+	MatchedAmount Decimal `json:"matched_amount"`
+	Price         Decimal `json:"price"`
+	FeeRateBps    string  `json:"fee_rate_bps"`
+	AssetID       string  `json:"asset_id"`
+	Outcome       string  `json:"outcome"`
+	Side          Side    `json:"side"`
 }
 
 // Trade represents a trade
@@ -231,9 +259,9 @@ type Trade struct {
 	Market          string       `json:"market"`
 	AssetID         string       `json:"asset_id"`
 	Side            Side         `json:"side"`
-	Size            string       `json:"size"`
+	Size            Decimal      `json:"size"`
 	FeeRateBps      string       `json:"fee_rate_bps"`
-	Price           string       `json:"price"`
+	Price           Decimal      `json:"price"`
 	Status          string       `json:"status"`
 	MatchTime       string       `json:"match_time"`
 	LastUpdate      string       `json:"last_update"`
@@ -249,7 +277,7 @@ type Trade struct {
 // MarketPrice represents market price data
 type MarketPrice struct {
 	T int64   `json:"t"` // timestamp
-	P float64 `json:"p"` // price
+	P Decimal `json:"p"` // price
 }
 
 // PriceHistoryFilterParams represents price history filter parameters
@@ -292,8 +320,8 @@ type OrderMarketCancelParams struct {
 
 // OrderSummary represents order summary
 type OrderSummary struct {
-	Price string `json:"price"`
-	Size  string `json:"size"`
+	Price Decimal `json:"price"`
+	Size  Decimal `json:"size"`
 }
 
 // OrderBookSummary represents order book summary
@@ -303,7 +331,7 @@ type OrderBookSummary struct {
 	Timestamp    string         `json:"timestamp"`
 	Bids         []OrderSummary `json:"bids"`
 	Asks         []OrderSummary `json:"asks"`
-	MinOrderSize string         `json:"min_order_size"`
+	MinOrderSize Decimal        `json:"min_order_size"`
 	TickSize     string         `json:"tick_size"`
 	NegRisk      bool           `json:"neg_risk"`
 	Hash         string         `json:"hash"`
@@ -323,10 +351,12 @@ type BalanceAllowanceParams struct {
 	TokenID   *string   `json:"token_id,omitempty"`
 }
 
-// BalanceAllowanceResponse represents balance allowance response
+// BalanceAllowanceResponse represents balance allowance response. Amounts
+// are in base units; Allowances is keyed by spender contract address.
 type BalanceAllowanceResponse struct {
-	Balance   string `json:"balance"`
-	Allowance string `json:"allowance"`
+	Balance    string            `json:"balance"`
+	Allowance  string            `json:"allowance,omitempty"`
+	Allowances map[string]string `json:"allowances,omitempty"`
 }
 
 // OrderScoringParams represents order scoring parameters
@@ -404,14 +434,14 @@ type MarketTradeEvent struct {
 		OptimizedProfilePicture string `json:"optimized_profile_picture"`
 		Pseudonym               string `json:"pseudonym"`
 	} `json:"user"`
-	Side            Side   `json:"side"`
-	Size            string `json:"size"`
-	FeeRateBps      string `json:"fee_rate_bps"`
-	Price           string `json:"price"`
-	Outcome         string `json:"outcome"`
-	OutcomeIndex    int    `json:"outcome_index"`
-	TransactionHash string `json:"transaction_hash"`
-	Timestamp       string `json:"timestamp"`
+	Side            Side    `json:"side"`
+	Size            Decimal `json:"size"`
+	FeeRateBps      string  `json:"fee_rate_bps"`
+	Price           Decimal `json:"price"`
+	Outcome         string  `json:"outcome"`
+	OutcomeIndex    int     `json:"outcome_index"`
+	TransactionHash string  `json:"transaction_hash"`
+	Timestamp       string  `json:"timestamp"`
 }
 
 // BookParams represents book parameters
@@ -426,8 +456,8 @@ type UserEarning struct {
 	ConditionID  string  `json:"condition_id"`
 	AssetAddress string  `json:"asset_address"`
 	MakerAddress string  `json:"maker_address"`
-	Earnings     float64 `json:"earnings"`
-	AssetRate    float64 `json:"asset_rate"`
+	Earnings     Decimal `json:"earnings"`
+	AssetRate    Decimal `json:"asset_rate"`
 }
 
 // TotalUserEarning represents total user earning
@@ -435,18 +465,18 @@ type TotalUserEarning struct {
 	Date         string  `json:"date"`
 	AssetAddress string  `json:"asset_address"`
 	MakerAddress string  `json:"maker_address"`
-	Earnings     float64 `json:"earnings"`
-	AssetRate    float64 `json:"asset_rate"`
+	Earnings     Decimal `json:"earnings"`
+	AssetRate    Decimal `json:"asset_rate"`
 }
 
 // RewardsPercentages represents rewards percentages
-type RewardsPercentages map[string]float64
+type RewardsPercentages map[string]Decimal
 
 // Token represents token data
 type Token struct {
 	TokenID string  `json:"token_id"`
 	Outcome string  `json:"outcome"`
-	Price   float64 `json:"price"`
+	Price   Decimal `json:"price"`
 }
 
 // RewardsConfig represents rewards configuration
@@ -454,8 +484,8 @@ type RewardsConfig struct {
 	AssetAddress string  `json:"asset_address"`
 	StartDate    string  `json:"start_date"`
 	EndDate      string  `json:"end_date"`
-	RatePerDay   float64 `json:"rate_per_day"`
-	TotalRewards float64 `json:"total_rewards"`
+	RatePerDay   Decimal `json:"rate_per_day"`
+	TotalRewards Decimal `json:"total_rewards"`
 }
 
 // MarketReward represents market reward
@@ -465,8 +495,8 @@ type MarketReward struct {
 	MarketSlug       string          `json:"market_slug"`
 	EventSlug        string          `json:"event_slug"`
 	Image            string          `json:"image"`
-	RewardsMaxSpread float64         `json:"rewards_max_spread"`
-	RewardsMinSize   float64         `json:"rewards_min_size"`
+	RewardsMaxSpread Decimal         `json:"rewards_max_spread"`
+	RewardsMinSize   Decimal         `json:"rewards_min_size"`
 	Tokens           []Token         `json:"tokens"`
 	RewardsConfig    []RewardsConfig `json:"rewards_config"`
 }
@@ -474,8 +504,8 @@ type MarketReward struct {
 // Earning represents earning data
 type Earning struct {
 	AssetAddress string  `json:"asset_address"`
-	Earnings     float64 `json:"earnings"`
-	AssetRate    float64 `json:"asset_rate"`
+	Earnings     Decimal `json:"earnings"`
+	AssetRate    Decimal `json:"asset_rate"`
 }
 
 // UserRewardsEarning represents user rewards earning
@@ -485,13 +515,13 @@ type UserRewardsEarning struct {
 	MarketSlug            string          `json:"market_slug"`
 	EventSlug             string          `json:"event_slug"`
 	Image                 string          `json:"image"`
-	RewardsMaxSpread      float64         `json:"rewards_max_spread"`
-	RewardsMinSize        float64         `json:"rewards_min_size"`
-	MarketCompetitiveness float64         `json:"market_competitiveness"`
+	RewardsMaxSpread      Decimal         `json:"rewards_max_spread"`
+	RewardsMinSize        Decimal         `json:"rewards_min_size"`
+	MarketCompetitiveness Decimal         `json:"market_competitiveness"`
 	Tokens                []Token         `json:"tokens"`
 	RewardsConfig         []RewardsConfig `json:"rewards_config"`
 	MakerAddress          string          `json:"maker_address"`
-	EarningPercentage     float64         `json:"earning_percentage"`
+	EarningPercentage     Decimal         `json:"earning_percentage"`
 	Earnings              []Earning       `json:"earnings"`
 }
 
@@ -504,9 +534,9 @@ type BuilderTrade struct {
 	Market          string     `json:"market"`
 	AssetID         string     `json:"assetId"`
 	Side            string     `json:"side"`
-	Size            string     `json:"size"`
-	SizeUSDC        string     `json:"sizeUsdc"`
-	Price           string     `json:"price"`
+	Size            Decimal    `json:"size"`
+	SizeUSDC        Decimal    `json:"sizeUsdc"`
+	Price           Decimal    `json:"price"`
 	Status          string     `json:"status"`
 	Outcome         string     `json:"outcome"`
 	OutcomeIndex    int        `json:"outcomeIndex"`
@@ -515,8 +545,8 @@ type BuilderTrade struct {
 	TransactionHash string     `json:"transactionHash"`
 	MatchTime       string     `json:"matchTime"`
 	BucketIndex     int        `json:"bucketIndex"`
-	Fee             string     `json:"fee"`
-	FeeUSDC         string     `json:"feeUsdc"`
+	Fee             Decimal    `json:"fee"`
+	FeeUSDC         Decimal    `json:"feeUsdc"`
 	ErrMsg          *string    `json:"err_msg,omitempty"`
 	CreatedAt       *time.Time `json:"createdAt,omitempty"`
 	UpdatedAt       *time.Time `json:"updatedAt,omitempty"`
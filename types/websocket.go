@@ -2,6 +2,7 @@ package types
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 )
 
@@ -16,8 +17,17 @@ const (
 	EventTypePriceChange    EventType = "price_change"
 	EventTypeTickSizeChange EventType = "tick_size_change"
 	EventTypeLastTradePrice EventType = "last_trade_price"
+
+	// Custom feature events, sent when the subscription enables custom features
+	EventTypeBestBidAsk     EventType = "best_bid_ask"
+	EventTypeNewMarket      EventType = "new_market"
+	EventTypeMarketResolved EventType = "market_resolved"
 )
 
+// ErrUnknownEventType is returned by ParseMarketChannelMessage for event types
+// this SDK does not know about yet
+var ErrUnknownEventType = errors.New("unknown event_type")
+
 // Note: OrderSummary and Side types are already defined in types.go
 
 // BookMessage represents a full orderbook snapshot or update
@@ -53,13 +63,13 @@ func (m *BookMessage) Validate() error {
 
 // PriceChange represents an individual price level change
 type PriceChange struct {
-	AssetID string `json:"asset_id"`
-	Price   string `json:"price"`
-	Size    string `json:"size"`
-	Side    Side   `json:"side"`
-	Hash    string `json:"hash"`
-	BestBid string `json:"best_bid"`
-	BestAsk string `json:"best_ask"`
+	AssetID string  `json:"asset_id"`
+	Price   Decimal `json:"price"`
+	Size    Decimal `json:"size"`
+	Side    Side    `json:"side"`
+	Hash    string  `json:"hash"`
+	BestBid Decimal `json:"best_bid"`
+	BestAsk Decimal `json:"best_ask"`
 }
 
 // Validate validates the PriceChange
@@ -67,11 +77,12 @@ func (pc *PriceChange) Validate() error {
 	if pc.AssetID == "" {
 		return fmt.Errorf("asset_id is required")
 	}
-	if pc.Price == "" {
-		return fmt.Errorf("price is required")
+	if pc.Price.Sign() <= 0 {
+		return fmt.Errorf("price must be positive")
 	}
-	if pc.Size == "" {
-		return fmt.Errorf("size is required")
+	// A size of zero removes the price level
+	if pc.Size.Sign() < 0 {
+		return fmt.Errorf("size cannot be negative")
 	}
 	if pc.Side != SideBuy && pc.Side != SideSell {
 		return fmt.Errorf("invalid side: must be 'BUY' or 'SELL', got '%s'", pc.Side)
@@ -117,8 +128,8 @@ type TickSizeChangeMessage struct {
 	EventType   EventType `json:"event_type"`
 	AssetID     string    `json:"asset_id"`
 	Market      string    `json:"market"`
-	OldTickSize string    `json:"old_tick_size"`
-	NewTickSize string    `json:"new_tick_size"`
+	OldTickSize Decimal   `json:"old_tick_size"`
+	NewTickSize Decimal   `json:"new_tick_size"`
 	Timestamp   string    `json:"timestamp"`
 }
 
@@ -133,11 +144,11 @@ func (m *TickSizeChangeMessage) Validate() error {
 	if m.Market == "" {
 		return fmt.Errorf("market is required")
 	}
-	if m.OldTickSize == "" {
-		return fmt.Errorf("old_tick_size is required")
+	if m.OldTickSize.Sign() <= 0 {
+		return fmt.Errorf("old_tick_size must be positive")
 	}
-	if m.NewTickSize == "" {
-		return fmt.Errorf("new_tick_size is required")
+	if m.NewTickSize.Sign() <= 0 {
+		return fmt.Errorf("new_tick_size must be positive")
 	}
 	if m.Timestamp == "" {
 		return fmt.Errorf("timestamp is required")
@@ -150,9 +161,9 @@ type LastTradePriceMessage struct {
 	EventType  EventType `json:"event_type"`
 	AssetID    string    `json:"asset_id"`
 	Market     string    `json:"market"`
-	Price      string    `json:"price"`
+	Price      Decimal   `json:"price"`
 	Side       Side      `json:"side"`
-	Size       string    `json:"size"`
+	Size       Decimal   `json:"size"`
 	FeeRateBps string    `json:"fee_rate_bps"`
 	Timestamp  string    `json:"timestamp"`
 }
@@ -168,14 +179,115 @@ func (m *LastTradePriceMessage) Validate() error {
 	if m.Market == "" {
 		return fmt.Errorf("market is required")
 	}
-	if m.Price == "" {
-		return fmt.Errorf("price is required")
+	if m.Price.Sign() <= 0 {
+		return fmt.Errorf("price must be positive")
 	}
 	if m.Side != SideBuy && m.Side != SideSell {
 		return fmt.Errorf("invalid side: must be 'BUY' or 'SELL', got '%s'", m.Side)
 	}
-	if m.Size == "" {
-		return fmt.Errorf("size is required")
+	if m.Size.Sign() <= 0 {
+		return fmt.Errorf("size must be positive")
+	}
+	if m.Timestamp == "" {
+		return fmt.Errorf("timestamp is required")
+	}
+	return nil
+}
+
+// BestBidAskMessage represents a change of the best bid or best ask of an asset
+type BestBidAskMessage struct {
+	EventType EventType `json:"event_type"`
+	AssetID   string    `json:"asset_id"`
+	Market    string    `json:"market"`
+	BestBid   Decimal   `json:"best_bid"`
+	BestAsk   Decimal   `json:"best_ask"`
+	Spread    Decimal   `json:"spread"`
+	Timestamp string    `json:"timestamp"`
+}
+
+// Validate validates the BestBidAskMessage
+func (m *BestBidAskMessage) Validate() error {
+	if m.EventType != EventTypeBestBidAsk {
+		return fmt.Errorf("invalid event_type: expected 'best_bid_ask', got '%s'", m.EventType)
+	}
+	if m.AssetID == "" {
+		return fmt.Errorf("asset_id is required")
+	}
+	if m.Market == "" {
+		return fmt.Errorf("market is required")
+	}
+	if m.Timestamp == "" {
+		return fmt.Errorf("timestamp is required")
+	}
+	return nil
+}
+
+// EventMessage describes the event a new or resolved market belongs to
+type EventMessage struct {
+	ID          string `json:"id"`
+	Ticker      string `json:"ticker"`
+	Slug        string `json:"slug"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// NewMarketMessage represents the creation of a new market
+type NewMarketMessage struct {
+	EventType    EventType     `json:"event_type"`
+	ID           string        `json:"id"`
+	Question     string        `json:"question"`
+	Market       string        `json:"market"`
+	Slug         string        `json:"slug"`
+	Description  string        `json:"description"`
+	AssetIDs     []string      `json:"assets_ids"`
+	Outcomes     []string      `json:"outcomes"`
+	EventMessage *EventMessage `json:"event_message,omitempty"`
+	Timestamp    string        `json:"timestamp"`
+}
+
+// Validate validates the NewMarketMessage
+func (m *NewMarketMessage) Validate() error {
+	if m.EventType != EventTypeNewMarket {
+		return fmt.Errorf("invalid event_type: expected 'new_market', got '%s'", m.EventType)
+	}
+	if m.Market == "" {
+		return fmt.Errorf("market is required")
+	}
+	if len(m.AssetIDs) == 0 {
+		return fmt.Errorf("assets_ids array cannot be empty")
+	}
+	if m.Timestamp == "" {
+		return fmt.Errorf("timestamp is required")
+	}
+	return nil
+}
+
+// MarketResolvedMessage represents the resolution of a market
+type MarketResolvedMessage struct {
+	EventType      EventType     `json:"event_type"`
+	ID             string        `json:"id"`
+	Question       string        `json:"question"`
+	Market         string        `json:"market"`
+	Slug           string        `json:"slug"`
+	Description    string        `json:"description"`
+	AssetIDs       []string      `json:"assets_ids"`
+	Outcomes       []string      `json:"outcomes"`
+	WinningAssetID string        `json:"winning_asset_id"`
+	WinningOutcome string        `json:"winning_outcome"`
+	EventMessage   *EventMessage `json:"event_message,omitempty"`
+	Timestamp      string        `json:"timestamp"`
+}
+
+// Validate validates the MarketResolvedMessage
+func (m *MarketResolvedMessage) Validate() error {
+	if m.EventType != EventTypeMarketResolved {
+		return fmt.Errorf("invalid event_type: expected 'market_resolved', got '%s'", m.EventType)
+	}
+	if m.Market == "" {
+		return fmt.Errorf("market is required")
+	}
+	if m.WinningAssetID == "" {
+		return fmt.Errorf("winning_asset_id is required")
 	}
 	if m.Timestamp == "" {
 		return fmt.Errorf("timestamp is required")
@@ -209,6 +321,21 @@ func (m *LastTradePriceMessage) GetEventType() EventType {
 	return m.EventType
 }
 
+// GetEventType returns the event type for BestBidAskMessage
+func (m *BestBidAskMessage) GetEventType() EventType {
+	return m.EventType
+}
+
+// GetEventType returns the event type for NewMarketMessage
+func (m *NewMarketMessage) GetEventType() EventType {
+	return m.EventType
+}
+
+// GetEventType returns the event type for MarketResolvedMessage
+func (m *MarketResolvedMessage) GetEventType() EventType {
+	return m.EventType
+}
+
 // ParseMarketChannelMessage parses and validates a WebSocket message
 func ParseMarketChannelMessage(data []byte) (MarketChannelMessage, error) {
 	// First, unmarshal just to get the event_type
@@ -262,8 +389,38 @@ func ParseMarketChannelMessage(data []byte) (MarketChannelMessage, error) {
 		}
 		return &msg, nil
 
+	case EventTypeBestBidAsk:
+		var msg BestBidAskMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return nil, fmt.Errorf("failed to parse best_bid_ask message: %w", err)
+		}
+		if err := msg.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid best_bid_ask message: %w", err)
+		}
+		return &msg, nil
+
+	case EventTypeNewMarket:
+		var msg NewMarketMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return nil, fmt.Errorf("failed to parse new_market message: %w", err)
+		}
+		if err := msg.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid new_market message: %w", err)
+		}
+		return &msg, nil
+
+	case EventTypeMarketResolved:
+		var msg MarketResolvedMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return nil, fmt.Errorf("failed to parse market_resolved message: %w", err)
+		}
+		if err := msg.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid market_resolved message: %w", err)
+		}
+		return &msg, nil
+
 	default:
-		return nil, fmt.Errorf("unknown event_type: %s", eventTypeWrapper.EventType)
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEventType, eventTypeWrapper.EventType)
 	}
 }
 
@@ -300,3 +457,27 @@ func AsLastTradePriceMessage(msg MarketChannelMessage) (*LastTradePriceMessage,
 	}
 	return nil, false
 }
+
+// AsBestBidAskMessage attempts to cast to BestBidAskMessage
+func AsBestBidAskMessage(msg MarketChannelMessage) (*BestBidAskMessage, bool) {
+	if m, ok := msg.(*BestBidAskMessage); ok {
+		return m, true
+	}
+	return nil, false
+}
+
+// AsNewMarketMessage attempts to cast to NewMarketMessage
+func AsNewMarketMessage(msg MarketChannelMessage) (*NewMarketMessage, bool) {
+	if m, ok := msg.(*NewMarketMessage); ok {
+		return m, true
+	}
+	return nil, false
+}
+
+// AsMarketResolvedMessage attempts to cast to MarketResolvedMessage
+func AsMarketResolvedMessage(msg MarketChannelMessage) (*MarketResolvedMessage, bool) {
+	if m, ok := msg.(*MarketResolvedMessage); ok {
+		return m, true
+	}
+	return nil, false
+}
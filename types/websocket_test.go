@@ -0,0 +1,37 @@
+package types
+
+import "testing"
+
+func TestParseMarketChannelMessageDecimals(t *testing.T) {
+	msg, err := ParseMarketChannelMessage([]byte(`{"event_type":"price_change","market":"0x1","timestamp":"1700000000000",` +
+		`"price_changes":[{"asset_id":"a","price":"0.5","size":0,"side":"SELL","hash":"h","best_bid":0.49,"best_ask":"0.5"}]}`))
+	if err != nil {
+		t.Fatalf("ParseMarketChannelMessage failed: %v", err)
+	}
+	pc := msg.(*PriceChangeMessage).PriceChanges[0]
+	if pc.Price.String() != "0.5" || !pc.Size.IsZero() || pc.BestBid.String() != "0.49" || pc.BestAsk.String() != "0.5" {
+		t.Errorf("price change = %+v", pc)
+	}
+
+	msg, err = ParseMarketChannelMessage([]byte(`{"event_type":"tick_size_change","asset_id":"a","market":"0x1",` +
+		`"old_tick_size":"0.01","new_tick_size":0.001,"timestamp":"1700000000000"}`))
+	if err != nil {
+		t.Fatalf("ParseMarketChannelMessage failed: %v", err)
+	}
+	if m := msg.(*TickSizeChangeMessage); m.OldTickSize.String() != "0.01" || m.NewTickSize.String() != "0.001" {
+		t.Errorf("tick sizes = %s %s", m.OldTickSize, m.NewTickSize)
+	}
+
+	invalid := []string{
+		`{"event_type":"last_trade_price","asset_id":"a","market":"0x1","price":"abc","side":"BUY","size":"1","timestamp":"1"}`,
+		`{"event_type":"last_trade_price","asset_id":"a","market":"0x1","price":"0","side":"BUY","size":"1","timestamp":"1"}`,
+		`{"event_type":"last_trade_price","asset_id":"a","market":"0x1","price":"0.5","side":"BUY","timestamp":"1"}`,
+		`{"event_type":"price_change","market":"0x1","timestamp":"1","price_changes":[{"asset_id":"a","price":"0.5","size":"-1","side":"BUY","hash":"h"}]}`,
+		`{"event_type":"tick_size_change","asset_id":"a","market":"0x1","old_tick_size":"0.01","new_tick_size":"","timestamp":"1"}`,
+	}
+	for _, data := range invalid {
+		if _, err := ParseMarketChannelMessage([]byte(data)); err == nil {
+			t.Errorf("ParseMarketChannelMessage accepted %s", data)
+		}
+	}
+}